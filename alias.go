@@ -0,0 +1,246 @@
+package comply
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ControlAliases maps the control names and IDs used in research files to
+// canonical requirement IDs, in the format of schema/control-mapping.json.
+// Keys starting with "$" are comments.
+type ControlAliases struct {
+	Names map[string]string `json:"mappings"`          // e.g., "SecNumCloud 3.2 - Immunity from CLOUD Act" → "CTL-LEGAL-001"
+	IDs   map[string]string `json:"researchIdAliases"` // e.g., "CTL-CRYPTO-001" → "CTL-ENCRYPTION-004"
+}
+
+// LoadControlAliases loads and merges control alias files; later files
+// override earlier ones.
+func LoadControlAliases(paths ...string) (*ControlAliases, error) {
+	aliases := &ControlAliases{Names: map[string]string{}, IDs: map[string]string{}}
+	for _, path := range paths {
+		var a ControlAliases
+		if err := ReadJSON(path, &a); err != nil {
+			return nil, fmt.Errorf("loading control aliases: %w", err)
+		}
+		maps.Copy(aliases.Names, a.Names)
+		maps.Copy(aliases.IDs, a.IDs)
+	}
+	return aliases, nil
+}
+
+// Resolve returns the canonical requirement ID for a research control ID or
+// name. IDs are matched exactly and names ignoring case, spacing and
+// punctuation. A name also matches the part of an alias after its
+// "<regulation> - " prefix when all such aliases agree.
+func (ca *ControlAliases) Resolve(controlID, controlName string) (id, via string, ok bool) {
+	if ca == nil {
+		return "", "", false
+	}
+	if id, ok := ca.IDs[controlID]; ok && !strings.HasPrefix(controlID, "$") {
+		return id, "id", true
+	}
+	for _, key := range []string{controlName, controlID} {
+		if key == "" {
+			continue
+		}
+		norm := normalizeControlName(key)
+		var short []string
+		for _, alias := range slices.Sorted(maps.Keys(ca.Names)) {
+			id := ca.Names[alias]
+			if strings.HasPrefix(alias, "$") {
+				continue
+			}
+			if normalizeControlName(alias) == norm {
+				return id, "name", true
+			}
+			if _, name, found := strings.Cut(alias, " - "); found && normalizeControlName(name) == norm {
+				short = append(short, id)
+			}
+		}
+		if slices.Sort(short); len(short) > 0 && short[0] == short[len(short)-1] {
+			return short[0], "name", true
+		}
+	}
+	return "", "", false
+}
+
+// ids returns the ID aliases, or nil for nil aliases.
+func (ca *ControlAliases) ids() map[string]string {
+	if ca == nil {
+		return nil
+	}
+	return ca.IDs
+}
+
+func normalizeControlName(s string) string {
+	return strings.Join(nameTokens(s), " ")
+}
+
+// nameTokens splits s into lower-case words and numbers.
+func nameTokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ControlResolution reports how ResolveControls rewrote the findings.
+type ControlResolution struct {
+	Resolved   []ResolvedControl   `json:"resolved,omitempty"`
+	Unresolved []UnresolvedControl `json:"unresolved,omitempty"`
+}
+
+// ResolvedControl is a finding whose control was rewritten from an alias.
+type ResolvedControl struct {
+	Index         int    `json:"index"`
+	From          string `json:"from"`
+	RequirementID string `json:"requirementId"`
+	Via           string `json:"via"` // "id" or "name"
+}
+
+// UnresolvedControl is a finding whose control is not a requirement of the
+// framework, with the closest requirements.
+type UnresolvedControl struct {
+	Index       int                     `json:"index"`
+	ControlID   string                  `json:"controlId"`
+	ControlName string                  `json:"controlName,omitempty"`
+	Suggestions []RequirementSuggestion `json:"suggestions,omitempty"`
+}
+
+// ResolveControls rewrites the ControlID of every finding that matches an
+// alias to its canonical requirement ID. When framework is not nil,
+// ControlName is set to the requirement's name, and findings whose control
+// is not a requirement are reported with suggestions. aliases may be nil.
+func (ri *ResearchInput) ResolveControls(aliases *ControlAliases, framework *ComplianceFramework) *ControlResolution {
+	res := &ControlResolution{}
+	for i := range ri.Findings {
+		f := &ri.Findings[i]
+		if id, via, ok := aliases.Resolve(f.ControlID, f.ControlName); ok {
+			from := f.ControlID
+			if via == "name" && f.ControlName != "" {
+				from = f.ControlName
+			}
+			res.Resolved = append(res.Resolved, ResolvedControl{Index: i, From: from, RequirementID: id, Via: via})
+			f.ControlID = id
+		}
+		if framework == nil {
+			continue
+		}
+		if r := framework.GetRequirement(f.ControlID); r != nil {
+			f.ControlName = r.Name
+			continue
+		}
+		// Requirements that are themselves aliases are not worth suggesting.
+		suggestions := slices.DeleteFunc(framework.SuggestRequirements(f.ControlID+" "+f.ControlName, len(aliases.ids())+3), func(s RequirementSuggestion) bool {
+			_, alias := aliases.ids()[s.RequirementID]
+			return alias
+		})
+		res.Unresolved = append(res.Unresolved, UnresolvedControl{
+			Index:       i,
+			ControlID:   f.ControlID,
+			ControlName: f.ControlName,
+			Suggestions: suggestions[:min(3, len(suggestions))],
+		})
+	}
+	return res
+}
+
+// RequirementSuggestion is a requirement that resembles a control name.
+type RequirementSuggestion struct {
+	RequirementID string  `json:"requirementId"`
+	Name          string  `json:"name"`
+	Score         float64 `json:"score"` // 0 to 1
+}
+
+// String renders the suggestion as "ID (Name)".
+func (s RequirementSuggestion) String() string {
+	return fmt.Sprintf("%s (%s)", s.RequirementID, s.Name)
+}
+
+// minSuggestionScore is the score below which requirements are not suggested.
+const minSuggestionScore = 0.3
+
+// SuggestRequirements returns up to limit requirements whose Name and
+// Keywords share the most words with text, best first. Words match when
+// they are equal or, for longer words, one edit apart.
+func (cf *ComplianceFramework) SuggestRequirements(text string, limit int) []RequirementSuggestion {
+	query := suggestionTokens(text)
+	if len(query) == 0 {
+		return nil
+	}
+	var suggestions []RequirementSuggestion
+	for _, r := range cf.Requirements {
+		name := suggestionTokens(r.Name)
+		words := append(slices.Clone(name), suggestionTokens(strings.Join(r.Keywords, " "))...)
+		matched := 0
+		for _, q := range query {
+			if slices.ContainsFunc(words, func(w string) bool { return similarWord(q, w) }) {
+				matched++
+			}
+		}
+		score := min(1, 2*float64(matched)/float64(len(query)+len(name)))
+		if score >= minSuggestionScore {
+			suggestions = append(suggestions, RequirementSuggestion{RequirementID: r.ID, Name: r.Name, Score: float64(int(score*100)) / 100})
+		}
+	}
+	slices.SortStableFunc(suggestions, func(a, b RequirementSuggestion) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), naturalCompare(a.RequirementID, b.RequirementID))
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// stopWords are ignored when suggesting requirements.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "by": true, "ctl": true, "for": true, "from": true,
+	"in": true, "of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+func suggestionTokens(s string) []string {
+	var tokens []string
+	for _, t := range nameTokens(s) {
+		if !stopWords[t] && !slices.Contains(tokens, t) {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// similarWord reports whether a and b are equal or, when both have at least
+// five letters, differ by one edit.
+func similarWord(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) < 5 || len(b) < 5 {
+		return false
+	}
+	return editDistance(a, b) <= 1
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,121 @@
+package comply
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveControls(t *testing.T) {
+	aliases, err := LoadControlAliases("schema/control-mapping.json")
+	if err != nil {
+		t.Fatalf("LoadControlAliases failed: %v", err)
+	}
+	cf := &ComplianceFramework{Requirements: []Requirement{
+		{ID: "CTL-LEGAL-001", Name: "Immunity from CLOUD Act", Keywords: []string{"cloud-act", "extraterritorial"}},
+		{ID: "CTL-LEGAL-002", Name: "Immunity from FISA 702"},
+		{ID: "CTL-ENCRYPTION-002", Name: "Encryption Key Storage in EU"},
+		{ID: "CTL-ENCRYPTION-004", Name: "Data Encrypted at Rest"},
+		{ID: "CTL-CRYPTO-004", Name: "Encryption Key Storage in EU"},
+	}}
+	ri := &ResearchInput{Findings: []ResearchFinding{
+		{ControlID: "CTL-CRYPTO-001"},
+		{ControlName: "SecNumCloud 3.2 - Immunity from CLOUD Act"},
+		{ControlID: "X-1", ControlName: "immunity from  FISA-702"},
+		{ControlID: "CTL-NEW-001", ControlName: "Encryption key storage in the EU"},
+		{ControlID: "CTL-NEW-002", ControlName: "Imunity from extraterritorial law"},
+		{ControlID: "CTL-LEGAL-002", ControlName: "FISA"},
+	}}
+
+	res := ri.ResolveControls(aliases, cf)
+	var ids []string
+	for _, f := range ri.Findings {
+		ids = append(ids, f.ControlID)
+	}
+	if got := strings.Join(ids, ","); got != "CTL-ENCRYPTION-004,CTL-LEGAL-001,CTL-LEGAL-002,CTL-NEW-001,CTL-NEW-002,CTL-LEGAL-002" {
+		t.Errorf("unexpected control IDs %s", got)
+	}
+	if ri.Findings[5].ControlName != "Immunity from FISA 702" {
+		t.Errorf("expected the canonical requirement name, got %q", ri.Findings[5].ControlName)
+	}
+	if len(res.Resolved) != 3 || res.Resolved[1].From != "SecNumCloud 3.2 - Immunity from CLOUD Act" || res.Resolved[0].Via != "id" {
+		t.Errorf("unexpected resolved controls %+v", res.Resolved)
+	}
+	if len(res.Unresolved) != 2 {
+		t.Fatalf("expected 2 unresolved controls, got %+v", res.Unresolved)
+	}
+	if s := res.Unresolved[0].Suggestions; len(s) != 1 || s[0].RequirementID != "CTL-ENCRYPTION-002" {
+		t.Errorf("expected CTL-ENCRYPTION-002 without its alias CTL-CRYPTO-004, got %+v", s)
+	}
+	if s := res.Unresolved[1].Suggestions; len(s) == 0 || s[0].RequirementID != "CTL-LEGAL-001" {
+		t.Errorf("expected a keyword match on CTL-LEGAL-001, got %+v", s)
+	}
+
+	result := ri.Validate(cf)
+	var suggested []string
+	for _, w := range result.Warnings {
+		suggested = append(suggested, w.Suggestions...)
+	}
+	if !slices.Contains(suggested, "CTL-ENCRYPTION-002 (Encryption Key Storage in EU)") {
+		t.Errorf("expected validation warnings with suggestions, got %v", suggested)
+	}
+}
+
+func TestLoadResearchInputWithAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "research.json")
+	data := `{"metadata": {"researchDate": "2026-01-01"}, "findings": [{"controlId": "CTL-DORA-002", "solutionId": "aws", "jurisdictionIds": ["EU"], "status": "compliant", "notes": ""}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ri, err := LoadResearchInput(path, "schema/control-mapping.json")
+	if err != nil {
+		t.Fatalf("LoadResearchInput failed: %v", err)
+	}
+	if ri.Findings[0].ControlID != "CTL-THIRDPARTY-002" {
+		t.Errorf("expected alias to be applied, got %s", ri.Findings[0].ControlID)
+	}
+	if m := ri.ToMappings(); m[0].RequirementID != "CTL-THIRDPARTY-002" {
+		t.Errorf("expected mapping for the canonical ID, got %s", m[0].RequirementID)
+	}
+}
+
+func TestLoadResearchInputInvalidValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "research.json")
+	data := `{"metadata": {"researchDate": "2026-01-01"}, "findings": [
+		{"controlId": "R1", "solutionId": "aws", "jurisdictionIds": ["FR"], "status": "compliant", "zone": "green", "notes": ""},
+		{"controlId": "R1", "solutionId": "aws", "jurisdictionIds": ["DE"], "status": "partial", "zone": "orange", "confidence": "certain", "notes": ""}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ri, err := LoadResearchInput(path)
+	if err != nil {
+		t.Fatalf("expected invalid values to be reported by Validate, got %v", err)
+	}
+	if ri.Findings[1].Zone != "" {
+		t.Errorf("expected the invalid zone to be dropped, got %q", ri.Findings[1].Zone)
+	}
+
+	cf := &ComplianceFramework{Requirements: []Requirement{{ID: "R1"}}, Solutions: []Solution{{ID: "aws"}}}
+	result := ri.Validate(cf)
+	var got []string
+	for _, e := range result.Errors {
+		got = append(got, fmt.Sprintf("[%d] %s %s", e.Index, e.Field, e.Value))
+	}
+	if result.Valid || strings.Join(got, ", ") != "[1] zone orange, [1] confidence certain" {
+		t.Errorf("expected per-finding zone and confidence errors, got %v", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{{"immunity", "imunity", 1}, {"kitten", "sitting", 3}, {"", "abc", 3}, {"same", "same", 0}} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
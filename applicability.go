@@ -0,0 +1,339 @@
+package comply
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ApplicabilityClause names the part of the model that was evaluated.
+type ApplicabilityClause string
+
+const (
+	ClauseJurisdiction    ApplicabilityClause = "jurisdiction"
+	ClauseRegulatedEntity ApplicabilityClause = "regulatedEntity"
+	ClauseEntityTypes     ApplicabilityClause = "entityTypes"
+	ClauseSectors         ApplicabilityClause = "sectors"
+	ClauseDataTypes       ApplicabilityClause = "dataTypes"
+	ClauseSizes           ApplicabilityClause = "sizes"
+	ClauseConditions      ApplicabilityClause = "conditions"
+)
+
+// ClauseResult explains how a single applicability clause was evaluated.
+type ClauseResult struct {
+	Clause      ApplicabilityClause `json:"clause"`
+	Matched     bool                `json:"matched"`
+	Values      []string            `json:"values,omitempty"` // matched values, or required values when not matched
+	Explanation string              `json:"explanation"`
+}
+
+// RegulationApplicability explains whether a regulation applies to a profile.
+type RegulationApplicability struct {
+	RegulationID     string         `json:"regulationId"`
+	ShortName        string         `json:"shortName,omitempty"`
+	JurisdictionID   string         `json:"jurisdictionId"`
+	Applicable       bool           `json:"applicable"`
+	NeedsReview      bool           `json:"needsReview,omitempty"`
+	MatchedEntityIDs []string       `json:"matchedEntityIds,omitempty"`
+	Clauses          []ClauseResult `json:"clauses"`
+}
+
+// RequirementApplicability explains whether a requirement applies to a profile.
+type RequirementApplicability struct {
+	RequirementID string              `json:"requirementId"`
+	Name          string              `json:"name,omitempty"`
+	RegulationID  string              `json:"regulationId"`
+	Severity      RequirementSeverity `json:"severity,omitempty"`
+	Applicable    bool                `json:"applicable"`
+	NeedsReview   bool                `json:"needsReview,omitempty"` // free-form conditions that need a human decision
+	Clauses       []ClauseResult      `json:"clauses"`
+}
+
+// ApplicabilityResult contains the regulations and requirements that apply to a profile.
+type ApplicabilityResult struct {
+	Profile      OrganizationProfile        `json:"profile"`
+	EntityTypes  []string                   `json:"entityTypes,omitempty"` // profile entity types plus those implied by matched regulated entities
+	Regulations  []RegulationApplicability  `json:"regulations"`
+	Requirements []RequirementApplicability `json:"requirements"`
+	Excluded     []RequirementApplicability `json:"excluded,omitempty"`
+}
+
+// ApplicableRequirementIDs returns the IDs of the applicable requirements.
+func (ar *ApplicabilityResult) ApplicableRequirementIDs() []string {
+	ids := make([]string, len(ar.Requirements))
+	for i, r := range ar.Requirements {
+		ids[i] = r.RequirementID
+	}
+	return ids
+}
+
+// Applicability evaluates which regulations and requirements apply to an
+// organization profile.
+//
+// A regulation applies when it is in force in one of the profile's
+// jurisdictions (see ApplicableRegulations) and, if the regulation defines
+// regulated entities, the profile matches at least one of them by entity ID
+// or by sector and size; an entity without sector or size constraints only
+// matches by ID. Requirements of applicable regulations are then checked
+// against their Applicability clauses, with the entity types implied by that
+// regulation's matched entities. Free-form conditions cannot be
+// evaluated, so requirements that carry them are kept and flagged for review.
+func (cf *ComplianceFramework) Applicability(profile *OrganizationProfile) *ApplicabilityResult {
+	result := &ApplicabilityResult{Profile: *profile}
+
+	h := cf.Hierarchy()
+	profileTypes := normalizeAll(profile.EntityTypes)
+	entityTypes := profileTypes
+	// regEntityTypes holds, per applicable regulation, the profile entity
+	// types plus those implied by the regulation's matched entities.
+	regEntityTypes := make(map[string][]string)
+
+	for _, reg := range cf.Regulations {
+		if reg.Status == RegulationSuperseded {
+			continue
+		}
+		var jurMatches []string
+		for _, jurID := range profile.JurisdictionIDs {
+			if reg.JurisdictionID == "" || h.AppliesTo(reg.JurisdictionID, jurID) {
+				jurMatches = append(jurMatches, jurID)
+			}
+		}
+		if len(jurMatches) == 0 {
+			continue
+		}
+
+		ra := RegulationApplicability{
+			RegulationID:   reg.ID,
+			ShortName:      reg.ShortName,
+			JurisdictionID: reg.JurisdictionID,
+			Applicable:     true,
+		}
+		ra.Clauses = append(ra.Clauses, ClauseResult{
+			Clause:      ClauseJurisdiction,
+			Matched:     true,
+			Values:      jurMatches,
+			Explanation: fmt.Sprintf("in force in %s via %s", strings.Join(jurMatches, ", "), jurisdictionLabel(reg.JurisdictionID)),
+		})
+
+		regTypes := profileTypes
+		entities := cf.regulatedEntitiesFor(reg)
+		if len(entities) > 0 {
+			clause, matched, review := matchRegulatedEntities(profile, entities)
+			ra.Clauses = append(ra.Clauses, clause)
+			ra.MatchedEntityIDs = matched
+			ra.Applicable = len(matched) > 0 || review
+			ra.NeedsReview = review
+			for _, e := range entities {
+				if slices.Contains(matched, e.ID) {
+					// A matched entity implies its classification, e.g. "Essential Entity" -> "essential-entity",
+					// for the requirements of this regulation only.
+					regTypes = appendUnique(slices.Clone(regTypes), normalize(e.ID), normalize(e.Name))
+					entityTypes = appendUnique(slices.Clone(entityTypes), normalize(e.ID), normalize(e.Name))
+				}
+			}
+		}
+
+		result.Regulations = append(result.Regulations, ra)
+		if ra.Applicable {
+			regEntityTypes[reg.ID] = regTypes
+		}
+	}
+
+	result.EntityTypes = entityTypes
+
+	for _, req := range cf.Requirements {
+		regTypes, ok := regEntityTypes[req.RegulationID]
+		if !ok {
+			continue
+		}
+		ra := evaluateRequirement(&req, profile, regTypes)
+		if ra.Applicable {
+			result.Requirements = append(result.Requirements, ra)
+		} else {
+			result.Excluded = append(result.Excluded, ra)
+		}
+	}
+
+	return result
+}
+
+// regulatedEntitiesFor returns the entities nested in a regulation plus the
+// top-level entities that reference it.
+func (cf *ComplianceFramework) regulatedEntitiesFor(reg Regulation) []RegulatedEntity {
+	entities := append([]RegulatedEntity(nil), reg.RegulatedEntities...)
+	for _, e := range cf.RegulatedEntities {
+		if e.RegulationID == reg.ID && !slices.ContainsFunc(entities, func(x RegulatedEntity) bool { return x.ID == e.ID }) {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+func matchRegulatedEntities(profile *OrganizationProfile, entities []RegulatedEntity) (clause ClauseResult, matched []string, review bool) {
+	clause.Clause = ClauseRegulatedEntity
+	profileTypes := normalizeAll(profile.EntityTypes)
+	profileSectors := normalizeAll(profile.Sectors)
+
+	var reasons []string
+	for _, e := range entities {
+		if slices.Contains(profileTypes, normalize(e.ID)) || slices.Contains(profileTypes, normalize(e.Name)) {
+			matched = append(matched, e.ID)
+			reasons = append(reasons, fmt.Sprintf("%s by classification", e.ID))
+			continue
+		}
+		// Without sector or size constraints an entity is only matched by
+		// classification.
+		if len(e.Sectors) == 0 && len(e.Sizes) == 0 {
+			continue
+		}
+		reason := e.ID + " by size"
+		if len(e.Sectors) > 0 {
+			sectors := intersect(profileSectors, normalizeAll(e.Sectors))
+			if len(sectors) == 0 {
+				continue
+			}
+			reason = fmt.Sprintf("%s by sector %s", e.ID, strings.Join(sectors, ", "))
+		}
+		switch {
+		case len(e.Sizes) == 0:
+			matched = append(matched, e.ID)
+			reasons = append(reasons, reason)
+		case slices.Contains(e.Sizes, profile.Size):
+			matched = append(matched, e.ID)
+			if len(e.Sectors) == 0 {
+				reason += " " + string(profile.Size)
+			}
+			reasons = append(reasons, reason)
+		case profile.Size == "":
+			review = true
+			reasons = append(reasons, fmt.Sprintf("%s; size unknown (%s)", reason, e.Criteria))
+		}
+	}
+
+	if len(matched) == 0 && len(profile.EntityTypes) == 0 && len(profile.Sectors) == 0 {
+		review = true
+		reasons = append(reasons, "profile has no sectors or entity types; regulated-entity scope cannot be determined")
+	}
+
+	clause.Matched = len(matched) > 0
+	clause.Values = matched
+	if len(reasons) == 0 {
+		clause.Explanation = "profile does not match any regulated entity"
+		for _, e := range entities {
+			clause.Values = append(clause.Values, e.ID)
+		}
+	} else {
+		clause.Explanation = strings.Join(reasons, "; ")
+	}
+	return clause, matched, review
+}
+
+func evaluateRequirement(req *Requirement, profile *OrganizationProfile, entityTypes []string) RequirementApplicability {
+	ra := RequirementApplicability{
+		RequirementID: req.ID,
+		Name:          req.Name,
+		RegulationID:  req.RegulationID,
+		Severity:      req.Severity,
+		Applicable:    true,
+	}
+
+	a := req.Applicability
+	if a == nil {
+		ra.Clauses = append(ra.Clauses, ClauseResult{
+			Clause:      ClauseJurisdiction,
+			Matched:     true,
+			Explanation: fmt.Sprintf("no applicability restrictions; applies wherever %s applies", req.RegulationID),
+		})
+		return ra
+	}
+
+	check := func(clause ApplicabilityClause, required, have []string) {
+		if len(required) == 0 {
+			return
+		}
+		common := intersect(have, normalizeAll(required))
+		cr := ClauseResult{Clause: clause, Matched: len(common) > 0}
+		if cr.Matched {
+			cr.Values = common
+			cr.Explanation = fmt.Sprintf("matches %s", strings.Join(common, ", "))
+		} else {
+			cr.Values = required
+			cr.Explanation = fmt.Sprintf("requires one of %s", strings.Join(required, ", "))
+			ra.Applicable = false
+		}
+		ra.Clauses = append(ra.Clauses, cr)
+	}
+
+	check(ClauseEntityTypes, a.EntityTypes, entityTypes)
+	check(ClauseSectors, a.Sectors, normalizeAll(profile.Sectors))
+	check(ClauseDataTypes, a.DataTypes, normalizeAll(profile.DataTypes))
+
+	if len(a.Sizes) > 0 {
+		sizes := make([]string, len(a.Sizes))
+		for i, s := range a.Sizes {
+			sizes[i] = string(s)
+		}
+		if profile.Size == "" {
+			ra.NeedsReview = true
+			ra.Clauses = append(ra.Clauses, ClauseResult{
+				Clause:      ClauseSizes,
+				Values:      sizes,
+				Explanation: fmt.Sprintf("requires size %s; profile size unknown", strings.Join(sizes, ", ")),
+			})
+		} else {
+			check(ClauseSizes, sizes, []string{string(profile.Size)})
+		}
+	}
+
+	if a.Conditions != "" {
+		ra.NeedsReview = true
+		ra.Clauses = append(ra.Clauses, ClauseResult{
+			Clause:      ClauseConditions,
+			Explanation: a.Conditions,
+		})
+	}
+
+	return ra
+}
+
+func jurisdictionLabel(id string) string {
+	if id == "" {
+		return "global scope"
+	}
+	return id
+}
+
+// normalize lowercases a value and replaces spaces and underscores with
+// hyphens so that "Essential Entity" matches "essential-entity".
+func normalize(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	return strings.NewReplacer(" ", "-", "_", "-").Replace(v)
+}
+
+func normalizeAll(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = appendUnique(result, normalize(v))
+	}
+	return result
+}
+
+func appendUnique(values []string, add ...string) []string {
+	for _, v := range add {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// intersect returns the values in a that also appear in b, in a's order.
+func intersect(a, b []string) []string {
+	var result []string
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package comply
+
+import (
+	"slices"
+	"testing"
+)
+
+func applicabilityTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+			{ID: "UK", Type: JurisdictionCountry},
+		},
+		Regulations: []Regulation{
+			{ID: "EU-GDPR", JurisdictionID: "EU", Status: RegulationEnforceable},
+			{ID: "EU-NIS2", JurisdictionID: "EU", Status: RegulationEnforceable},
+			{ID: "EU-DORA", JurisdictionID: "EU", Status: RegulationEnforceable},
+			{ID: "UK-DPA", JurisdictionID: "UK", Status: RegulationEnforceable},
+		},
+		RegulatedEntities: []RegulatedEntity{
+			{ID: "NIS2-ESSENTIAL", Name: "Essential Entity", RegulationID: "EU-NIS2", Sectors: []string{"energy", "health"}, Sizes: []OrganizationSize{SizeLarge}},
+			{ID: "DORA-FINANCIAL", Name: "Financial Entity", RegulationID: "EU-DORA", Sectors: []string{"banking"}},
+		},
+		Requirements: []Requirement{
+			{ID: "GDPR-01", RegulationID: "EU-GDPR", Applicability: &Applicability{DataTypes: []string{"personal-data"}}},
+			{ID: "GDPR-02", RegulationID: "EU-GDPR", Applicability: &Applicability{DataTypes: []string{"health-data"}}},
+			{ID: "NIS2-01", RegulationID: "EU-NIS2", Applicability: &Applicability{EntityTypes: []string{"essential-entity"}}},
+			{ID: "NIS2-02", RegulationID: "EU-NIS2", Applicability: &Applicability{Conditions: "Only for cloud services"}},
+			{ID: "DORA-01", RegulationID: "EU-DORA"},
+			{ID: "UK-01", RegulationID: "UK-DPA"},
+		},
+	}
+}
+
+func TestApplicabilityForEnergyOperator(t *testing.T) {
+	cf := applicabilityTestFramework()
+	result := cf.Applicability(&OrganizationProfile{
+		JurisdictionIDs: []string{"FR"},
+		Sectors:         []string{"Energy"},
+		DataTypes:       []string{"personal-data"},
+		Size:            SizeLarge,
+	})
+
+	ids := result.ApplicableRequirementIDs()
+	if !slices.Equal(ids, []string{"GDPR-01", "NIS2-01", "NIS2-02"}) {
+		t.Errorf("expected [GDPR-01 NIS2-01 NIS2-02], got %v", ids)
+	}
+
+	for _, r := range result.Regulations {
+		switch r.RegulationID {
+		case "EU-NIS2":
+			if !r.Applicable || !slices.Equal(r.MatchedEntityIDs, []string{"NIS2-ESSENTIAL"}) {
+				t.Errorf("expected NIS2 to apply via NIS2-ESSENTIAL, got %+v", r)
+			}
+		case "EU-DORA":
+			if r.Applicable {
+				t.Error("expected DORA not to apply to an energy operator")
+			}
+		case "UK-DPA":
+			t.Error("expected UK-DPA not to be evaluated for FR")
+		}
+	}
+
+	for _, r := range result.Requirements {
+		if r.RequirementID == "NIS2-02" && !r.NeedsReview {
+			t.Error("expected free-form conditions to be flagged for review")
+		}
+		if r.RequirementID == "NIS2-01" {
+			if len(r.Clauses) != 1 || r.Clauses[0].Clause != ClauseEntityTypes || !r.Clauses[0].Matched {
+				t.Errorf("expected entityTypes clause match via implied classification, got %+v", r.Clauses)
+			}
+		}
+	}
+
+	if len(result.Excluded) != 1 || result.Excluded[0].RequirementID != "GDPR-02" {
+		t.Errorf("expected GDPR-02 to be excluded, got %+v", result.Excluded)
+	}
+}
+
+func TestApplicabilitySizeAndClassification(t *testing.T) {
+	cf := applicabilityTestFramework()
+
+	small := cf.Applicability(&OrganizationProfile{
+		JurisdictionIDs: []string{"FR"},
+		Sectors:         []string{"energy"},
+		Size:            SizeSmall,
+	})
+	if slices.Contains(small.ApplicableRequirementIDs(), "NIS2-01") {
+		t.Error("expected NIS2 not to apply to a small energy company")
+	}
+
+	unknown := cf.Applicability(&OrganizationProfile{
+		JurisdictionIDs: []string{"FR"},
+		Sectors:         []string{"energy"},
+	})
+	for _, r := range unknown.Regulations {
+		if r.RegulationID == "EU-NIS2" && (!r.Applicable || !r.NeedsReview) {
+			t.Errorf("expected NIS2 to need review when size is unknown, got %+v", r)
+		}
+	}
+
+	classified := cf.Applicability(&OrganizationProfile{
+		JurisdictionIDs: []string{"FR"},
+		EntityTypes:     []string{"DORA-FINANCIAL"},
+	})
+	if !slices.Contains(classified.ApplicableRequirementIDs(), "DORA-01") {
+		t.Error("expected DORA to apply to an explicitly classified financial entity")
+	}
+}
+
+func TestApplicabilityEntityScope(t *testing.T) {
+	cf := applicabilityTestFramework()
+	cf.RegulatedEntities = append(cf.RegulatedEntities,
+		RegulatedEntity{ID: "GDPR-CONTROLLER", Name: "Controller", RegulationID: "EU-GDPR"},
+		RegulatedEntity{ID: "DORA-LARGE", Name: "Large Financial Entity", RegulationID: "EU-DORA", Sizes: []OrganizationSize{SizeLarge}},
+	)
+	// DORA-01 must not pick up the classification implied by NIS2.
+	cf.Requirements = append(cf.Requirements, Requirement{ID: "DORA-02", RegulationID: "EU-DORA", Applicability: &Applicability{EntityTypes: []string{"essential-entity"}}})
+
+	result := cf.Applicability(&OrganizationProfile{
+		JurisdictionIDs: []string{"FR"},
+		Sectors:         []string{"energy"},
+		Size:            SizeLarge,
+	})
+	for _, r := range result.Regulations {
+		switch r.RegulationID {
+		case "EU-GDPR":
+			if r.Applicable {
+				t.Errorf("expected an entity without sector or size constraints not to match, got %+v", r)
+			}
+		case "EU-DORA":
+			if !r.Applicable || r.Clauses[1].Explanation != "DORA-LARGE by size large" {
+				t.Errorf("expected DORA to apply by size, got %+v", r)
+			}
+		}
+	}
+	ids := result.ApplicableRequirementIDs()
+	if slices.Contains(ids, "DORA-02") || !slices.Contains(ids, "NIS2-01") {
+		t.Errorf("expected implied entity types to be scoped to their regulation, got %v", ids)
+	}
+}
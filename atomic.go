@@ -0,0 +1,141 @@
+package comply
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic writes data to path through a temporary file in the same
+// directory that is then renamed over path, so readers see either the old or
+// the new content and a failed write leaves the old file in place. An
+// existing file keeps its permissions; new files are created with mode 0644.
+func WriteFileAtomic(path string, data []byte) error {
+	f, err := createAtomic(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.abort()
+		return fmt.Errorf("writing file %s: %w", path, err)
+	}
+	return f.commit()
+}
+
+// atomicFile is a temporary file that replaces path when committed.
+type atomicFile struct {
+	*os.File
+	path string
+}
+
+func createAtomic(path string) (*atomicFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file for %s: %w", path, err)
+	}
+	return &atomicFile{File: f, path: path}, nil
+}
+
+// commit flushes the temporary file to disk and renames it over path.
+func (f *atomicFile) commit() error {
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(f.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	err := f.Chmod(mode)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), f.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("writing file %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// abort discards the temporary file.
+func (f *atomicFile) abort() {
+	f.Close()
+	os.Remove(f.Name())
+}
+
+// saveStaged saves the framework to a copy of dir in a sibling staging
+// directory and swaps the copy in, so that a failed save leaves dir as it
+// was. Files in dir that are not framework files are carried over. A
+// symlinked dir is resolved first, so the link stays in place and the
+// directory it points to is replaced.
+func (s *Saver) saveStaged(cf *ComplianceFramework, dir string) error {
+	dir = filepath.Clean(dir)
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	parent, base := filepath.Dir(dir), filepath.Base(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", parent, err)
+	}
+	staging, err := os.MkdirTemp(parent, "."+base+".staging-")
+	if err != nil {
+		return fmt.Errorf("creating staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging) // nothing left to remove once swapped in
+
+	info, err := os.Stat(dir)
+	exists := err == nil
+	switch {
+	case exists && !info.IsDir():
+		return fmt.Errorf("%s is not a directory", dir)
+	case exists:
+		if err := os.Chmod(staging, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("creating staging directory: %w", err)
+		}
+		if err := os.CopyFS(staging, os.DirFS(dir)); err != nil {
+			return fmt.Errorf("copying %s to staging directory: %w", dir, err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if err := os.Chmod(staging, 0755); err != nil {
+			return fmt.Errorf("creating staging directory: %w", err)
+		}
+	default:
+		return err
+	}
+
+	if err := s.saveFiles(cf, staging); err != nil {
+		return err
+	}
+	if !exists {
+		if err := os.Rename(staging, dir); err != nil {
+			return fmt.Errorf("moving staging directory to %s: %w", dir, err)
+		}
+		return nil
+	}
+
+	old := s.Backup
+	if old == "" {
+		old = staging + ".old"
+	} else if err := os.RemoveAll(old); err != nil {
+		return fmt.Errorf("removing previous backup %s: %w", old, err)
+	}
+	if err := os.Rename(dir, old); err != nil {
+		return fmt.Errorf("moving %s aside: %w", dir, err)
+	}
+	if err := os.Rename(staging, dir); err != nil {
+		if restoreErr := os.Rename(old, dir); restoreErr != nil {
+			return fmt.Errorf("moving staging directory to %s: %w (previous content is in %s)", dir, err, old)
+		}
+		return fmt.Errorf("moving staging directory to %s: %w", dir, err)
+	}
+	if s.Backup == "" {
+		if err := os.RemoveAll(old); err != nil {
+			return fmt.Errorf("removing previous content of %s: %w", dir, err)
+		}
+	}
+	return nil
+}
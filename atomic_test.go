@@ -0,0 +1,120 @@
+package comply
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+
+	if err := WriteFileAtomic(path, []byte("[]\n")); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Fatalf("expected new file with mode 0644, got %v, %v", info, err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomic(path, []byte("[1]\n")); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "[1]\n" {
+		t.Errorf("unexpected content %q", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode to be kept, got %v", info.Mode())
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected no temporary files to be left, got %v", entries)
+	}
+}
+
+func TestSaveFrameworkStaged(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "data")
+	backup := filepath.Join(root, "data.bak")
+
+	cf := &ComplianceFramework{
+		Name:          "Test",
+		Jurisdictions: []Jurisdiction{{ID: "EU", Name: "European Union", Type: JurisdictionSupranational}},
+	}
+	saver := &Saver{Staged: true, Backup: backup}
+	if err := saver.SaveFrameworkToDir(cf, dir); err != nil {
+		t.Fatalf("staged save to a new directory failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cf.Jurisdictions = append(cf.Jurisdictions, Jurisdiction{ID: "FR", Name: "France", Type: JurisdictionCountry})
+	if err := saver.SaveFrameworkToDir(cf, dir); err != nil {
+		t.Fatalf("staged save failed: %v", err)
+	}
+	loaded, err := LoadFrameworkFromDir(dir)
+	if err != nil || len(loaded.Jurisdictions) != 2 {
+		t.Fatalf("expected 2 jurisdictions after save, got %v, %v", loaded, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "notes" {
+		t.Errorf("expected other files to be carried over, got %q", data)
+	}
+	previous, err := LoadFrameworkFromDir(backup)
+	if err != nil || len(previous.Jurisdictions) != 1 {
+		t.Errorf("expected backup of the previous save, got %v, %v", previous, err)
+	}
+
+	// A save that fails halfway leaves the directory as it was.
+	if err := os.WriteFile(filepath.Join(dir, "mappings"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cf.Jurisdictions = cf.Jurisdictions[:1]
+	cf.Mappings = []RequirementMapping{{ID: "M1", SolutionID: "aws"}}
+	shards, _ := Sharding("mappings")
+	if err := (&Saver{Shards: shards, Staged: true}).SaveFrameworkToDir(cf, dir); err == nil {
+		t.Fatal("expected save to fail")
+	}
+	loaded, err = LoadFrameworkFromDir(dir)
+	if err != nil || len(loaded.Jurisdictions) != 2 {
+		t.Errorf("expected directory to be untouched, got %v, %v", loaded, err)
+	}
+	entries, _ := os.ReadDir(root)
+	for _, e := range entries {
+		if strings.Contains(e.Name(), "staging") {
+			t.Errorf("expected staging directory to be removed, found %s", e.Name())
+		}
+	}
+}
+
+func TestSaveFrameworkStagedSymlink(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(root, "releases", "v1")
+	link := filepath.Join(root, "current")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	cf := &ComplianceFramework{
+		Name:          "Test",
+		Jurisdictions: []Jurisdiction{{ID: "EU", Name: "European Union", Type: JurisdictionSupranational}},
+	}
+	if err := (&Saver{Staged: true}).SaveFrameworkToDir(cf, link); err != nil {
+		t.Fatalf("staged save through a symlink failed: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("expected %s to stay a symlink, got %v, %v", link, info, err)
+	}
+	loaded, err := LoadFrameworkFromDir(target)
+	if err != nil || len(loaded.Jurisdictions) != 1 {
+		t.Errorf("expected the link target to be saved, got %v, %v", loaded, err)
+	}
+}
@@ -0,0 +1,45 @@
+package comply
+
+import (
+	"fmt"
+	"os"
+)
+
+// LoadBundle loads a framework bundle: a single JSON or YAML file holding the
+// whole ComplianceFramework, including its metadata.
+func LoadBundle(path string) (*ComplianceFramework, error) {
+	return (&Loader{}).LoadBundle(path)
+}
+
+// LoadBundle loads a framework bundle according to the loader's mode.
+func (l *Loader) LoadBundle(path string) (*ComplianceFramework, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file %s: %w", path, err)
+	}
+	var cf ComplianceFramework
+	if err := l.decodeFile(path, data, &cf); err != nil {
+		return nil, err
+	}
+	if cf.SchemaVersion > SchemaVersion {
+		return nil, schemaError(cf.SchemaVersion, nil)
+	}
+	return &cf, nil
+}
+
+// SaveBundle saves a ComplianceFramework as a single bundle file in canonical
+// form, in YAML when path ends in .yaml or .yml and in JSON otherwise.
+// Comments of an existing YAML bundle are kept where possible. The file is
+// replaced atomically.
+func SaveBundle(cf *ComplianceFramework, path string) error {
+	format := FormatFromPath(path)
+	var existing []byte
+	if format == FormatYAML {
+		existing, _ = os.ReadFile(path)
+	}
+	data, err := MarshalBundle(cf, format, existing)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(path, data)
+}
@@ -0,0 +1,437 @@
+package comply
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Canonical returns a copy of the framework in canonical form, the form the
+// Saver writes:
+//   - collections are sorted by ID, with requirements and regulated entities
+//     grouped by regulation and zone assignments by solution and jurisdiction;
+//   - set-like string lists, such as Keywords, Tags and JurisdictionIDs, are
+//     sorted and deduplicated, and Evidence is deduplicated;
+//   - dates are written as YYYY-MM-DD, or YYYY-MM when only the month is known;
+//   - SchemaVersion is set to the version of this package.
+//
+// Sections, external references and enforcement actions keep their order.
+// cf is not modified.
+func (cf *ComplianceFramework) Canonical() *ComplianceFramework {
+	c := *cf
+	c.SchemaVersion = SchemaVersion
+	c.LastUpdated = normalizeDate(cf.LastUpdated)
+
+	c.Jurisdictions = canonicalEach(cf.Jurisdictions, func(j *Jurisdiction) {
+		j.MemberIDs = canonicalSet(j.MemberIDs)
+	})
+	slices.SortStableFunc(c.Jurisdictions, func(a, b Jurisdiction) int { return naturalCompare(a.ID, b.ID) })
+
+	c.Regulations = canonicalEach(cf.Regulations, canonicalRegulation)
+	slices.SortStableFunc(c.Regulations, func(a, b Regulation) int { return naturalCompare(a.ID, b.ID) })
+
+	c.Requirements = canonicalEach(cf.Requirements, canonicalRequirement)
+	slices.SortStableFunc(c.Requirements, func(a, b Requirement) int {
+		return cmp.Or(naturalCompare(a.RegulationID, b.RegulationID), naturalCompare(a.ID, b.ID))
+	})
+
+	c.RegulatedEntities = canonicalEach(cf.RegulatedEntities, canonicalRegulatedEntity)
+	sortRegulatedEntities(c.RegulatedEntities)
+
+	c.Solutions = canonicalEach(cf.Solutions, func(s *Solution) {
+		s.AvailableRegions = canonicalSet(s.AvailableRegions)
+		s.Certifications = canonicalSet(s.Certifications)
+		s.JurisdictionIDs = canonicalSet(s.JurisdictionIDs)
+		if s.OwnershipStructure != nil {
+			o := *s.OwnershipStructure
+			s.OwnershipStructure = &o
+		}
+	})
+	slices.SortStableFunc(c.Solutions, func(a, b Solution) int { return naturalCompare(a.ID, b.ID) })
+
+	c.ZoneAssignments = canonicalEach(cf.ZoneAssignments, func(z *ZoneAssignment) {
+		z.RegulationIDs = canonicalSet(z.RegulationIDs)
+	})
+	slices.SortStableFunc(c.ZoneAssignments, func(a, b ZoneAssignment) int {
+		return cmp.Or(
+			naturalCompare(a.SolutionID, b.SolutionID),
+			naturalCompare(a.JurisdictionID, b.JurisdictionID),
+			naturalCompare(a.DataCategory, b.DataCategory),
+			naturalCompare(a.EntityType, b.EntityType),
+			naturalCompare(a.ID, b.ID),
+		)
+	})
+
+	c.Mappings = canonicalEach(cf.Mappings, func(m *RequirementMapping) {
+		m.JurisdictionIDs = canonicalSet(m.JurisdictionIDs)
+		m.Evidence = dedupe(m.Evidence)
+		m.AssessmentDate = normalizeDate(m.AssessmentDate)
+	})
+	slices.SortStableFunc(c.Mappings, func(a, b RequirementMapping) int { return naturalCompare(a.ID, b.ID) })
+
+	c.EnforcementAssessments = canonicalEach(cf.EnforcementAssessments, func(e *EnforcementAssessment) {
+		e.AssessmentDate = normalizeDate(e.AssessmentDate)
+		e.RecentActions = slices.Clone(e.RecentActions)
+		for i := range e.RecentActions {
+			e.RecentActions[i].Date = normalizeDate(e.RecentActions[i].Date)
+		}
+	})
+	slices.SortStableFunc(c.EnforcementAssessments, func(a, b EnforcementAssessment) int { return naturalCompare(a.ID, b.ID) })
+
+	return &c
+}
+
+func canonicalRegulation(r *Regulation) {
+	r.AdoptedDate = normalizeDate(r.AdoptedDate)
+	r.EffectiveDate = normalizeDate(r.EffectiveDate)
+	r.EnforcementDate = normalizeDate(r.EnforcementDate)
+	r.Tags = canonicalSet(r.Tags)
+	r.Sections = slices.Clone(r.Sections)
+	for i := range r.Sections {
+		r.Sections[i].RequirementIDs = canonicalSet(r.Sections[i].RequirementIDs)
+	}
+	r.RegulatedEntities = canonicalEach(r.RegulatedEntities, canonicalRegulatedEntity)
+	sortRegulatedEntities(r.RegulatedEntities)
+}
+
+func canonicalRequirement(r *Requirement) {
+	r.Keywords = canonicalSet(r.Keywords)
+	r.RelatedIDs = canonicalSet(r.RelatedIDs)
+	r.EffectiveDate = normalizeDate(r.EffectiveDate)
+	if r.Applicability != nil {
+		a := *r.Applicability
+		a.EntityTypes = canonicalSet(a.EntityTypes)
+		a.Sectors = canonicalSet(a.Sectors)
+		a.DataTypes = canonicalSet(a.DataTypes)
+		a.Sizes = canonicalEnums(a.Sizes, OrganizationSizes)
+		r.Applicability = &a
+	}
+}
+
+func canonicalRegulatedEntity(e *RegulatedEntity) {
+	e.Sectors = canonicalSet(e.Sectors)
+	e.Sizes = canonicalEnums(e.Sizes, OrganizationSizes)
+	e.Examples = dedupe(e.Examples)
+}
+
+func sortRegulatedEntities(entities []RegulatedEntity) {
+	slices.SortStableFunc(entities, func(a, b RegulatedEntity) int {
+		return cmp.Or(naturalCompare(a.RegulationID, b.RegulationID), naturalCompare(a.ID, b.ID))
+	})
+}
+
+// canonicalEach returns a copy of items with fn applied to each item, or nil
+// if there are none.
+func canonicalEach[T any](items []T, fn func(*T)) []T {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]T, len(items))
+	copy(out, items)
+	for i := range out {
+		fn(&out[i])
+	}
+	return out
+}
+
+// canonicalSet returns the non-empty values of s, trimmed, sorted and
+// deduplicated, or nil if there are none.
+func canonicalSet(s []string) []string {
+	out := dedupe(s)
+	slices.SortFunc(out, naturalCompare)
+	return out
+}
+
+// canonicalEnums returns the values of s deduplicated and in the order of
+// allowed; unknown values sort last.
+func canonicalEnums[T ~string](s []T, allowed []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	out := slices.Clone(s)
+	rank := func(v T) int {
+		if i := slices.Index(allowed, v); i >= 0 {
+			return i
+		}
+		return len(allowed)
+	}
+	slices.SortFunc(out, func(a, b T) int { return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(string(a), string(b))) })
+	return slices.Compact(out)
+}
+
+// dedupe returns the non-empty values of s, trimmed, in their first order of
+// appearance, or nil if there are none.
+func dedupe(s []string) []string {
+	var out []string
+	for _, v := range s {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// naturalCompare compares strings with runs of digits compared by value, so
+// that "REQ-2" sorts before "REQ-10". Strings that differ only in leading
+// zeros fall back to byte order.
+func naturalCompare(a, b string) int {
+	return cmp.Or(naturalOrder(a, b), strings.Compare(a, b))
+}
+
+func naturalOrder(a, b string) int {
+	for a != "" && b != "" {
+		da, db := digitPrefix(a), digitPrefix(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if c := cmp.Or(cmp.Compare(len(na), len(nb)), strings.Compare(na, nb)); c != 0 {
+				return c
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// dateLayouts are the date formats normalizeDate recognizes.
+var dateLayouts = []string{
+	"2006-01-02", "2006-1-2", "2006/01/02", "2006/1/2",
+	time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05",
+	"2 January 2006", "January 2, 2006", "2 Jan 2006", "Jan 2, 2006",
+}
+
+// monthLayouts are the month formats normalizeDate recognizes.
+var monthLayouts = []string{"2006-01", "2006-1", "2006/01", "2006/1", "January 2006", "Jan 2006"}
+
+// normalizeDate rewrites a recognized date as YYYY-MM-DD, or YYYY-MM when it
+// names only a month. Other values, such as "2026" or "2026-H1", are only
+// trimmed.
+func normalizeDate(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	for _, layout := range monthLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01")
+		}
+	}
+	return s
+}
+
+// MarshalBundle encodes a framework bundle in canonical form. For YAML,
+// comments are carried over from existing, the previous content of the file.
+func MarshalBundle(cf *ComplianceFramework, format Format, existing []byte) ([]byte, error) {
+	return encodeFile(format, cf.Canonical(), existing)
+}
+
+// DetectSaver returns a Saver that writes in the format and sharding of the
+// framework files in fsys. The format is that of the framework metadata file,
+// or of the first collection file when there is none. It returns an error if
+// fsys holds no framework files.
+func DetectSaver(fsys fs.FS) (*Saver, error) {
+	names, err := frameworkFileNames(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no framework files found")
+	}
+	s := &Saver{Format: FormatFromPath(names[0])}
+	meta, err := findFile(fsys, "framework")
+	if err != nil {
+		return nil, err
+	}
+	if meta != "" {
+		s.Format = FormatFromPath(meta)
+	}
+	if sharded := ShardedCollections(fsys); len(sharded) > 0 {
+		if s.Shards, err = Sharding(sharded...); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// NonCanonicalFiles loads the framework in fsys and returns the names of the
+// files the saver would change: files whose content differs from their
+// canonical form, files it would create and files it would remove.
+func (s *Saver) NonCanonicalFiles(fsys fs.FS) ([]string, error) {
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+	out := fsWriter{fsys: fsys, files: MapFSWriter{}}
+	if err := s.SaveFramework(cf, out); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for name, canonical := range out.files {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		if err != nil || !bytes.Equal(data, canonical) {
+			changed = append(changed, name)
+		}
+	}
+	existing, err := frameworkFileNames(fsys)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range existing {
+		if _, ok := out.files[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// fsWriter writes files to memory while reading previous files from fsys,
+// so that YAML comments are kept as a save to fsys would keep them.
+type fsWriter struct {
+	fsys  fs.FS
+	files MapFSWriter
+}
+
+func (w fsWriter) WriteFile(name string, data []byte) error {
+	return w.files.WriteFile(name, data)
+}
+
+func (w fsWriter) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(w.fsys, name)
+}
+
+// frameworkFileNames returns the framework files in fsys, in any format and
+// including shards.
+func frameworkFileNames(fsys fs.FS) ([]string, error) {
+	var names []string
+	for _, f := range append(frameworkFiles(&ComplianceFramework{}), frameworkFile{base: "framework"}) {
+		for _, ext := range append(FormatJSON.extensions(), FormatYAML.extensions()...) {
+			if _, err := fs.Stat(fsys, f.base+ext); err == nil {
+				names = append(names, f.base+ext)
+			}
+		}
+		shards, err := shardFiles(fsys, f.base)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", f.base, err)
+		}
+		names = append(names, shards...)
+	}
+	return names, nil
+}
+
+// indentJSON indents compact JSON by two spaces. Arrays of strings, numbers
+// and other scalars stay on one line, as in the hand-written data files.
+func indentJSON(src []byte) []byte {
+	var buf bytes.Buffer
+	depth := 0
+	newline := func() {
+		buf.WriteByte('\n')
+		buf.WriteString(strings.Repeat("  ", depth))
+	}
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"':
+			end := jsonStringEnd(src, i)
+			buf.Write(src[i:end])
+			i = end - 1
+		case '{', '[':
+			if i+1 < len(src) && (src[i+1] == '}' || src[i+1] == ']') {
+				buf.Write(src[i : i+2])
+				i++
+				continue
+			}
+			if end := scalarArrayEnd(src, i); end > 0 {
+				writeInlineArray(&buf, src[i:end+1])
+				i = end
+				continue
+			}
+			buf.WriteByte(c)
+			depth++
+			newline()
+		case '}', ']':
+			depth--
+			newline()
+			buf.WriteByte(c)
+		case ',':
+			buf.WriteByte(',')
+			newline()
+		case ':':
+			buf.WriteString(": ")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.Bytes()
+}
+
+// jsonStringEnd returns the index just past the JSON string starting at i.
+func jsonStringEnd(src []byte, i int) int {
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(src)
+}
+
+// scalarArrayEnd returns the index of the ']' closing the array starting at
+// i, or -1 if the array is not made of scalars only.
+func scalarArrayEnd(src []byte, i int) int {
+	if src[i] != '[' {
+		return -1
+	}
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '"':
+			j = jsonStringEnd(src, j) - 1
+		case '{', '[':
+			return -1
+		case ']':
+			return j
+		}
+	}
+	return -1
+}
+
+// writeInlineArray writes a compact array of scalars with ", " separators.
+func writeInlineArray(buf *bytes.Buffer, src []byte) {
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '"':
+			end := jsonStringEnd(src, i)
+			buf.Write(src[i:end])
+			i = end - 1
+		case ',':
+			buf.WriteString(", ")
+		default:
+			buf.WriteByte(src[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package comply
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCanonical(t *testing.T) {
+	cf := &ComplianceFramework{
+		Name:        "Test",
+		LastUpdated: "2025/5/1",
+		Jurisdictions: []Jurisdiction{
+			{ID: "UK"},
+			{ID: "EU", MemberIDs: []string{"FR", "DE", "FR"}},
+		},
+		Requirements: []Requirement{
+			{ID: "REQ-10", RegulationID: "EU-GDPR"},
+			{ID: "REQ-2", RegulationID: "EU-GDPR", Keywords: []string{"sovereignty", " encryption", "sovereignty", ""}},
+			{ID: "REQ-1", RegulationID: "EU-NIS2", Applicability: &Applicability{Sizes: []OrganizationSize{SizeLarge, SizeMedium, SizeLarge}}},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M2", JurisdictionIDs: []string{"FR", "DE"}, Evidence: []string{"b", "a", "b"}, AssessmentDate: "2025-01-15T10:00:00Z"},
+			{ID: "M1", AssessmentDate: "2025-H1"},
+		},
+	}
+
+	c := cf.Canonical()
+
+	if c.LastUpdated != "2025-05-01" {
+		t.Errorf("expected normalized lastUpdated, got %q", c.LastUpdated)
+	}
+	if c.Jurisdictions[0].ID != "EU" || !slices.Equal(c.Jurisdictions[0].MemberIDs, []string{"DE", "FR"}) {
+		t.Errorf("unexpected jurisdictions %+v", c.Jurisdictions)
+	}
+	var reqIDs []string
+	for _, r := range c.Requirements {
+		reqIDs = append(reqIDs, r.ID)
+	}
+	if !slices.Equal(reqIDs, []string{"REQ-2", "REQ-10", "REQ-1"}) {
+		t.Errorf("expected requirements sorted by regulation then natural ID, got %v", reqIDs)
+	}
+	if !slices.Equal(c.Requirements[0].Keywords, []string{"encryption", "sovereignty"}) {
+		t.Errorf("unexpected keywords %v", c.Requirements[0].Keywords)
+	}
+	if !slices.Equal(c.Requirements[2].Applicability.Sizes, []OrganizationSize{SizeMedium, SizeLarge}) {
+		t.Errorf("expected sizes in enum order, got %v", c.Requirements[2].Applicability.Sizes)
+	}
+	m := c.Mappings[1]
+	if c.Mappings[0].ID != "M1" || !slices.Equal(m.JurisdictionIDs, []string{"DE", "FR"}) || !slices.Equal(m.Evidence, []string{"b", "a"}) {
+		t.Errorf("unexpected mappings %+v", c.Mappings)
+	}
+	if m.AssessmentDate != "2025-01-15" || c.Mappings[0].AssessmentDate != "2025-H1" {
+		t.Errorf("unexpected assessment dates %q, %q", m.AssessmentDate, c.Mappings[0].AssessmentDate)
+	}
+
+	// The original is left untouched.
+	if cf.Jurisdictions[0].ID != "UK" || !slices.Equal(cf.Jurisdictions[1].MemberIDs, []string{"FR", "DE", "FR"}) {
+		t.Errorf("Canonical modified the framework: %+v", cf.Jurisdictions)
+	}
+	if len(cf.Requirements[2].Applicability.Sizes) != 3 {
+		t.Errorf("Canonical modified applicability: %+v", cf.Requirements[2].Applicability)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	ids := []string{"REQ-10", "REQ-2", "REQ-1a", "REQ-01", "REQ-1", "A"}
+	slices.SortFunc(ids, naturalCompare)
+	want := []string{"A", "REQ-01", "REQ-1", "REQ-1a", "REQ-2", "REQ-10"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+}
+
+func TestNormalizeDate(t *testing.T) {
+	tests := map[string]string{
+		"2025-05-01":           "2025-05-01",
+		" 2025-5-1 ":           "2025-05-01",
+		"2025/05/01":           "2025-05-01",
+		"2025-05-01T12:00:00Z": "2025-05-01",
+		"1 May 2025":           "2025-05-01",
+		"May 1, 2025":          "2025-05-01",
+		"2025-5":               "2025-05",
+		"2025":                 "2025",
+		"2026-H1":              "2026-H1",
+		"":                     "",
+	}
+	for in, want := range tests {
+		if got := normalizeDate(in); got != want {
+			t.Errorf("normalizeDate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIndentJSON(t *testing.T) {
+	got := string(indentJSON([]byte(`{"a":["x","y, \"z\""],"b":[],"c":[{"d":1,"e":[1,2]}],"f":{}}`)))
+	want := `{
+  "a": ["x", "y, \"z\""],
+  "b": [],
+  "c": [
+    {
+      "d": 1,
+      "e": [1, 2]
+    }
+  ],
+  "f": {}
+}`
+	if got != want {
+		t.Errorf("unexpected indentation:\n%s", got)
+	}
+}
+
+func TestNonCanonicalFiles(t *testing.T) {
+	cf := &ComplianceFramework{
+		Name:          "Test",
+		Jurisdictions: []Jurisdiction{{ID: "EU", Name: "European Union", Type: JurisdictionSupranational}},
+		Mappings:      []RequirementMapping{{ID: "M1", SolutionID: "aws"}, {ID: "M2", SolutionID: "ovh"}},
+	}
+	shards, _ := Sharding("mappings")
+	out := MapFSWriter{}
+	if err := (&Saver{Format: FormatYAML, Shards: shards}).SaveFramework(cf, out); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	fsys := out
+
+	s, err := DetectSaver(fsys)
+	if err != nil {
+		t.Fatalf("DetectSaver failed: %v", err)
+	}
+	if s.Format != FormatYAML || s.Shards["mappings"] == nil || len(s.Shards) != 1 {
+		t.Errorf("unexpected detected saver %+v", s)
+	}
+	files, err := s.NonCanonicalFiles(fsys)
+	if err != nil {
+		t.Fatalf("NonCanonicalFiles failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected saved framework to be canonical, got %v", files)
+	}
+
+	fsys["jurisdictions.yaml"] = []byte("# Reviewed\n- {id: EU, name: European Union, type: supranational}\n")
+	fsys["zone-assignments/aws.json"] = []byte("[]\n")
+	files, err = s.NonCanonicalFiles(fsys)
+	if err != nil {
+		t.Fatalf("NonCanonicalFiles failed: %v", err)
+	}
+	if !slices.Equal(files, []string{"jurisdictions.yaml", "zone-assignments/aws.json"}) {
+		t.Errorf("expected reformatted and stale files, got %v", files)
+	}
+
+	if _, err := DetectSaver(fstest.MapFS{"README.md": {}}); err == nil {
+		t.Error("expected an error for a directory without framework files")
+	}
+}
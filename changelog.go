@@ -0,0 +1,231 @@
+package comply
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DataChangelog is a structured changelog of framework data releases, kept
+// separately from the code changelog. Releases are ordered newest first.
+type DataChangelog struct {
+	Project  string        `json:"project,omitempty"`
+	Releases []DataRelease `json:"releases"`
+	unknown  unknownFields
+}
+
+// UnmarshalJSON decodes a changelog, keeping the fields it does not know,
+// such as those of a hand-written project changelog.
+func (c *DataChangelog) UnmarshalJSON(data []byte) error {
+	type plain DataChangelog
+	var err error
+	c.unknown, err = unmarshalKnown(data, (*plain)(c), "DataChangelog")
+	return err
+}
+
+// MarshalJSON encodes a changelog, including the unknown fields it was decoded with.
+func (c DataChangelog) MarshalJSON() ([]byte, error) {
+	type plain DataChangelog
+	return marshalKnown(plain(c), c.unknown)
+}
+
+// DataRelease is the changelog entry for one framework data release.
+type DataRelease struct {
+	Version          string          `json:"version"`
+	PreviousVersion  string          `json:"previousVersion,omitempty"`
+	Date             string          `json:"date,omitempty"`
+	NewRegulations   []ChangelogItem `json:"newRegulations,omitempty"`
+	NewRequirements  []ChangelogItem `json:"newRequirements,omitempty"`
+	ChangedVerdicts  []VerdictChange `json:"changedVerdicts,omitempty"`
+	RemovedSolutions []ChangelogItem `json:"removedSolutions,omitempty"`
+	unknown          unknownFields
+}
+
+// UnmarshalJSON decodes a release, keeping the fields it does not know, such
+// as "highlights" or "added".
+func (r *DataRelease) UnmarshalJSON(data []byte) error {
+	type plain DataRelease
+	var err error
+	r.unknown, err = unmarshalKnown(data, (*plain)(r), "DataRelease")
+	return err
+}
+
+// MarshalJSON encodes a release, including the unknown fields it was decoded with.
+func (r DataRelease) MarshalJSON() ([]byte, error) {
+	type plain DataRelease
+	return marshalKnown(plain(r), r.unknown)
+}
+
+// ChangelogItem identifies an entity in a changelog entry.
+type ChangelogItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// String renders the item as "ID Name".
+func (i ChangelogItem) String() string {
+	if i.Name == "" {
+		return i.ID
+	}
+	return i.ID + " " + i.Name
+}
+
+// NewDataRelease builds a changelog entry from the diff between two framework
+// snapshots. Version and Date are taken from the new framework's Version and
+// LastUpdated.
+func NewDataRelease(old, new *ComplianceFramework) *DataRelease {
+	d := Diff(old, new)
+	r := &DataRelease{
+		Version:         new.Version,
+		PreviousVersion: old.Version,
+		Date:            new.LastUpdated,
+		ChangedVerdicts: d.Verdicts,
+	}
+	r.NewRegulations = changelogItems(d.Collection("regulations"), ChangeAdded, func(id string) string {
+		if reg := new.GetRegulation(id); reg != nil {
+			return reg.Name
+		}
+		return ""
+	})
+	r.NewRequirements = changelogItems(d.Collection("requirements"), ChangeAdded, func(id string) string {
+		if req := new.GetRequirement(id); req != nil {
+			return req.Name
+		}
+		return ""
+	})
+	r.RemovedSolutions = changelogItems(d.Collection("solutions"), ChangeRemoved, func(id string) string {
+		if sol := old.GetSolution(id); sol != nil {
+			return sol.Name
+		}
+		return ""
+	})
+	return r
+}
+
+func changelogItems(cd *CollectionDiff, t ChangeType, name func(id string) string) []ChangelogItem {
+	if cd == nil {
+		return nil
+	}
+	var items []ChangelogItem
+	for _, c := range cd.Changes {
+		if c.Type == t {
+			items = append(items, ChangelogItem{ID: c.ID, Name: name(c.ID)})
+		}
+	}
+	return items
+}
+
+// Empty reports whether the release has no entries.
+func (r *DataRelease) Empty() bool {
+	return len(r.NewRegulations) == 0 && len(r.NewRequirements) == 0 &&
+		len(r.ChangedVerdicts) == 0 && len(r.RemovedSolutions) == 0
+}
+
+// Set adds a release to the top of the changelog, replacing any release
+// with the same version in place. Fields of the replaced release that
+// DataRelease does not know are kept.
+func (c *DataChangelog) Set(r DataRelease) {
+	if i := slices.IndexFunc(c.Releases, func(e DataRelease) bool { return e.Version == r.Version }); i >= 0 {
+		if r.unknown == nil {
+			r.unknown = c.Releases[i].unknown
+		}
+		c.Releases[i] = r
+		return
+	}
+	c.Releases = slices.Insert(c.Releases, 0, r)
+}
+
+// Markdown renders the changelog as Markdown.
+func (c *DataChangelog) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Data Changelog\n\n")
+	sb.WriteString("All notable changes to the compliance framework data are documented in this file.\n")
+	for _, r := range c.Releases {
+		sb.WriteString("\n")
+		sb.WriteString(r.Markdown())
+	}
+	return sb.String()
+}
+
+// InsertMarkdown returns the Markdown changelog md with the release's
+// section added, leaving the rest of md untouched. A section for the same
+// version is replaced; otherwise the section goes before the first release
+// heading other than "[Unreleased]", or at the end. An empty md gets a new
+// changelog with just this release.
+func (r *DataRelease) InsertMarkdown(md string) string {
+	if strings.TrimSpace(md) == "" {
+		return (&DataChangelog{Releases: []DataRelease{*r}}).Markdown()
+	}
+	section := r.Markdown() + "\n"
+	lines := strings.SplitAfter(md, "\n")
+	heading := func(line string) (string, bool) {
+		rest, ok := strings.CutPrefix(line, "## [")
+		if !ok {
+			return "", false
+		}
+		version, _, ok := strings.Cut(rest, "]")
+		return version, ok
+	}
+
+	insert := -1
+	for i, line := range lines {
+		version, ok := heading(line)
+		if !ok || strings.EqualFold(version, "unreleased") {
+			continue
+		}
+		if version == orNone(r.Version) {
+			end := i + 1
+			for end < len(lines) && !strings.HasPrefix(lines[end], "## ") {
+				end++
+			}
+			if end == len(lines) {
+				section = strings.TrimSuffix(section, "\n")
+			}
+			return strings.Join(lines[:i], "") + section + strings.Join(lines[end:], "")
+		}
+		if insert < 0 {
+			insert = i
+		}
+	}
+	if insert >= 0 {
+		return strings.Join(lines[:insert], "") + section + strings.Join(lines[insert:], "")
+	}
+	if !strings.HasSuffix(md, "\n") {
+		md += "\n"
+	}
+	return md + "\n" + r.Markdown()
+}
+
+// Markdown renders the release as a Markdown section.
+func (r *DataRelease) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s]", orNone(r.Version))
+	if r.Date != "" {
+		fmt.Fprintf(&sb, " - %s", r.Date)
+	}
+	sb.WriteString("\n")
+	if r.Empty() {
+		sb.WriteString("\nNo regulation, requirement, verdict or solution changes.\n")
+		return sb.String()
+	}
+
+	items := func(title string, items []ChangelogItem) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", title)
+		for _, i := range items {
+			fmt.Fprintf(&sb, "- %s\n", i)
+		}
+	}
+	items("New Regulations", r.NewRegulations)
+	items("New Requirements", r.NewRequirements)
+	if len(r.ChangedVerdicts) > 0 {
+		sb.WriteString("\n### Changed Verdicts\n\n")
+		for _, v := range r.ChangedVerdicts {
+			fmt.Fprintf(&sb, "- %s\n", v)
+		}
+	}
+	items("Removed Solutions", r.RemovedSolutions)
+	return sb.String()
+}
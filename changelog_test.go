@@ -0,0 +1,96 @@
+package comply
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewDataRelease(t *testing.T) {
+	old, updated := diffTestFrameworks()
+	old.Solutions = []Solution{{ID: "aws", Name: "AWS"}, {ID: "legacy", Name: "Legacy Cloud"}}
+	updated.Solutions = []Solution{{ID: "aws", Name: "AWS"}}
+	updated.Regulations = []Regulation{{ID: "EU-DORA", Name: "DORA"}}
+	updated.LastUpdated = "2026-03-01"
+
+	r := NewDataRelease(old, updated)
+	if r.Version != "1.1.0" || r.PreviousVersion != "1.0.0" || r.Date != "2026-03-01" {
+		t.Errorf("unexpected release header %+v", r)
+	}
+	if len(r.NewRegulations) != 1 || r.NewRegulations[0].String() != "EU-DORA DORA" {
+		t.Errorf("expected new regulation EU-DORA, got %v", r.NewRegulations)
+	}
+	if len(r.NewRequirements) != 1 || r.NewRequirements[0] != (ChangelogItem{ID: "REQ-003", Name: "Added"}) {
+		t.Errorf("expected new requirement REQ-003, got %v", r.NewRequirements)
+	}
+	if len(r.ChangedVerdicts) != 1 || r.ChangedVerdicts[0].JurisdictionID != "FR" {
+		t.Errorf("expected one changed verdict in FR, got %v", r.ChangedVerdicts)
+	}
+	if len(r.RemovedSolutions) != 1 || r.RemovedSolutions[0].Name != "Legacy Cloud" {
+		t.Errorf("expected removed solution legacy, got %v", r.RemovedSolutions)
+	}
+
+	md := r.Markdown()
+	for _, want := range []string{"## [1.1.0] - 2026-03-01", "### New Regulations\n\n- EU-DORA DORA", "### Removed Solutions\n\n- legacy Legacy Cloud"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected Markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+}
+
+func TestDataChangelogSet(t *testing.T) {
+	c := &DataChangelog{}
+	c.Set(DataRelease{Version: "1.0.0"})
+	c.Set(DataRelease{Version: "1.1.0"})
+	c.Set(DataRelease{Version: "1.0.0", Date: "2026-01-02"})
+
+	if len(c.Releases) != 2 || c.Releases[0].Version != "1.1.0" || c.Releases[1].Date != "2026-01-02" {
+		t.Errorf("expected newest first with 1.0.0 replaced in place, got %+v", c.Releases)
+	}
+}
+
+func TestDataChangelogKeepsUnknownFields(t *testing.T) {
+	c, err := LoadDataChangelog("CHANGELOG.json")
+	if err != nil {
+		t.Fatalf("LoadDataChangelog failed: %v", err)
+	}
+	c.Set(DataRelease{Version: "0.3.0"})
+	c.Set(DataRelease{Version: "v0.1.0", Date: "2026-02-23"})
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Repository string `json:"repository"`
+		Releases   []struct {
+			Version    string            `json:"version"`
+			Highlights []json.RawMessage `json:"highlights"`
+		} `json:"releases"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Repository == "" || len(got.Releases) != 2 || got.Releases[1].Version != "v0.1.0" || len(got.Releases[1].Highlights) != 3 {
+		t.Errorf("expected hand-written fields to survive, got %s", data)
+	}
+}
+
+func TestDataReleaseInsertMarkdown(t *testing.T) {
+	r := &DataRelease{Version: "1.1.0", Date: "2026-03-01", NewRequirements: []ChangelogItem{{ID: "REQ-003"}}}
+	md := "# Changelog\n\nIntro.\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n\n### Added\n\n- Code release notes\n"
+
+	got := r.InsertMarkdown(md)
+	want := "# Changelog\n\nIntro.\n\n## [Unreleased]\n\n" + r.Markdown() + "\n## [1.0.0] - 2026-01-01\n\n### Added\n\n- Code release notes\n"
+	if got != want {
+		t.Errorf("unexpected Markdown:\n%s", got)
+	}
+
+	r.NewRequirements = append(r.NewRequirements, ChangelogItem{ID: "REQ-004"})
+	if again := r.InsertMarkdown(got); strings.Count(again, "## [1.1.0]") != 1 || !strings.Contains(again, "- REQ-004\n\n## [1.0.0]") {
+		t.Errorf("expected the 1.1.0 section to be replaced in place, got:\n%s", again)
+	}
+	if empty := r.InsertMarkdown(""); !strings.HasPrefix(empty, "# Data Changelog") {
+		t.Errorf("expected a new changelog, got:\n%s", empty)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdApplicable(args []string) {
+	fs := flag.NewFlagSet("applicable", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	profilePath := fs.String("profile", "", "Organization profile JSON file")
+	showExcluded := fs.Bool("excluded", false, "Also list requirements that do not apply and why")
+	format := fs.String("format", "table", "Output format (table, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if *profilePath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -profile is required")
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	profile, err := comply.LoadOrganizationProfile(*profilePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading profile: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := cf.Applicability(profile)
+
+	if *format == "json" {
+		outputJSON(result)
+		return
+	}
+
+	printApplicability(result, *showExcluded)
+}
+
+func printApplicability(result *comply.ApplicabilityResult, showExcluded bool) {
+	name := result.Profile.Name
+	if name == "" {
+		name = "(unnamed profile)"
+	}
+	fmt.Printf("Applicability for %s\n", name)
+	fmt.Printf("Jurisdictions: %s\n\n", strings.Join(result.Profile.JurisdictionIDs, ", "))
+
+	fmt.Println("Regulations:")
+	for _, r := range result.Regulations {
+		status := "applies"
+		switch {
+		case !r.Applicable:
+			status = "does not apply"
+		case r.NeedsReview:
+			status = "review"
+		}
+		fmt.Printf("  %-25s %s\n", r.RegulationID, status)
+		for _, c := range r.Clauses {
+			fmt.Printf("      %-16s %s\n", c.Clause+":", c.Explanation)
+		}
+	}
+	fmt.Println()
+
+	fmt.Printf("Applicable Requirements (%d):\n", len(result.Requirements))
+	printRequirementApplicability(result.Requirements)
+
+	if showExcluded && len(result.Excluded) > 0 {
+		fmt.Println()
+		fmt.Printf("Excluded Requirements (%d):\n", len(result.Excluded))
+		printRequirementApplicability(result.Excluded)
+	}
+}
+
+func printRequirementApplicability(reqs []comply.RequirementApplicability) {
+	for _, r := range reqs {
+		review := ""
+		if r.NeedsReview {
+			review = " [review]"
+		}
+		fmt.Printf("  %-25s %-20s %-8s%s\n", r.RequirementID, r.RegulationID, r.Severity, review)
+		for _, c := range r.Clauses {
+			fmt.Printf("      %-16s %s\n", c.Clause+":", c.Explanation)
+		}
+	}
+}
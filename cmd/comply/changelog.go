@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdChangelog(args []string) {
+	fset := flag.NewFlagSet("changelog", flag.ExitOnError)
+	file := fset.String("file", "", "Changelog JSON file to append the entry to (created if missing)")
+	markdown := fset.String("markdown", "", "Add the entry's section to this Markdown changelog (created if missing)")
+	version := fset.String("version", "", "Release version (default: the new framework's version)")
+	date := fset.String("date", "", "Release date (default: the new framework's lastUpdated, or today)")
+	format := fset.String("format", "markdown", "Output format when no -file is given (markdown, json)")
+	if err := fset.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fset.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: comply changelog [-file <changelog.json>] [-markdown <changelog.md>] [-version <v>] [-date <yyyy-mm-dd>] <old-dir> <new-dir>")
+		os.Exit(1)
+	}
+
+	old, err := comply.LoadFramework(fset.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fset.Arg(0), err)
+		os.Exit(1)
+	}
+	updated, err := comply.LoadFramework(fset.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fset.Arg(1), err)
+		os.Exit(1)
+	}
+
+	release := comply.NewDataRelease(old, updated)
+	if *version != "" {
+		release.Version = *version
+	}
+	if *date != "" {
+		release.Date = *date
+	}
+	if release.Date == "" {
+		release.Date = time.Now().Format(time.DateOnly)
+	}
+
+	if *file == "" {
+		if *format == "json" {
+			outputJSON(release)
+		} else {
+			fmt.Print(release.Markdown())
+		}
+		return
+	}
+
+	changelog, err := comply.LoadDataChangelog(*file)
+	if errors.Is(err, fs.ErrNotExist) {
+		changelog = &comply.DataChangelog{Project: updated.Name}
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading changelog: %v\n", err)
+		os.Exit(1)
+	}
+	changelog.Set(*release)
+
+	if err := comply.WriteJSON(*file, changelog, true); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing changelog: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Updated %s with release %s\n", *file, release.Version)
+
+	if *markdown != "" {
+		existing, err := os.ReadFile(*markdown)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Error reading Markdown: %v\n", err)
+			os.Exit(1)
+		}
+		if err := comply.WriteFileAtomic(*markdown, []byte(release.InsertMarkdown(string(existing)))); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing Markdown: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", *markdown)
+	}
+}
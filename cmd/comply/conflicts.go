@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdConflicts(args []string) {
+	fs := flag.NewFlagSet("conflicts", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format (text, json, markdown)")
+	researchFile := fs.String("research", "", "Research input file to check against the framework instead of the framework itself")
+	aliasFiles := fs.String("aliases", "", "Comma-separated control alias files applied to the research findings")
+	exitCode := fs.Bool("exit-code", false, "Exit with status 1 if there are conflicts")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: comply conflicts [-research <file> [-aliases <file,...>]] [-format text|json|markdown] [-exit-code] <dir>")
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+
+	var report *comply.ConflictReport
+	if *researchFile != "" {
+		research, err := comply.LoadResearchInput(*researchFile, splitList(*aliasFiles)...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading research file: %v\n", err)
+			os.Exit(1)
+		}
+		report = research.Conflicts(cf)
+	} else {
+		report = cf.Conflicts()
+	}
+
+	switch *format {
+	case "json":
+		outputJSON(report)
+	case "markdown", "md":
+		fmt.Print(report.Markdown())
+	default:
+		fmt.Print(report.Text())
+	}
+
+	if *exitCode && !report.Empty() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdConvert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	to := fs.String("to", "", "Output layout (json, yaml, bundle, zip)")
+	output := fs.String("o", "", "Output directory, bundle file (.json, .yaml) or .zip archive")
+	shard := fs.String("shard", "", "Collections to write as one file per shard, e.g. mappings,requirements, or none (default: as in the input)")
+	staged := fs.Bool("staged", false, "Write a directory output to a staging copy and swap it in, so a failed write leaves it untouched")
+	backup := fs.String("backup", "", "With -staged, keep the previous output directory here")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 1 || *to == "" || *output == "" {
+		fmt.Fprintln(os.Stderr, "Usage: comply convert -to json|yaml|bundle|zip -o <output> [-shard <collections>] [-staged [-backup <dir>]] <input>")
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	var collections []string
+	switch *shard {
+	case "":
+		if info, err := os.Stat(fs.Arg(0)); err == nil && info.IsDir() {
+			collections = comply.ShardedCollections(os.DirFS(fs.Arg(0)))
+		}
+	case "none":
+	default:
+		collections = splitList(*shard)
+	}
+	shards, err := comply.Sharding(collections...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *to {
+	case "json", "yaml":
+		format, _ := comply.ParseFormat(*to)
+		err = (&comply.Saver{Format: format, Shards: shards, Staged: *staged, Backup: *backup}).SaveFrameworkToDir(cf, *output)
+	case "bundle":
+		err = comply.SaveBundle(cf, *output)
+	case "zip":
+		err = (&comply.Saver{Shards: shards}).SaveFrameworkToZip(cf, *output)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown layout %q (allowed: json, yaml, bundle, zip)\n", *to)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s (%s)\n", *output, *to)
+}
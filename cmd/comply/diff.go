@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format (text, json, markdown)")
+	exitCode := fs.Bool("exit-code", false, "Exit with status 1 if the frameworks differ")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: comply diff [-format text|json|markdown] [-exit-code] <old-dir> <new-dir>")
+		os.Exit(1)
+	}
+
+	old, err := comply.LoadFramework(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+	updated, err := comply.LoadFramework(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", fs.Arg(1), err)
+		os.Exit(1)
+	}
+
+	d := comply.Diff(old, updated)
+
+	switch *format {
+	case "json":
+		outputJSON(d)
+	case "markdown", "md":
+		fmt.Print(d.Markdown())
+	default:
+		fmt.Print(d.Text())
+	}
+
+	if *exitCode && !d.Empty() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdFmt(args []string) {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	check := fs.Bool("check", false, "List files that are not in canonical form and exit 1 if there are any, without rewriting them")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: comply fmt [-check] <dir|bundle>...")
+		os.Exit(1)
+	}
+
+	var changed []string
+	for _, path := range fs.Args() {
+		files, err := fmtPath(path, *check)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		changed = append(changed, files...)
+	}
+	for _, f := range changed {
+		fmt.Println(f)
+	}
+	if *check && len(changed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// fmtPath rewrites a framework directory or bundle in canonical form, or
+// only checks it when check is set. It returns the files that differ from
+// their canonical form.
+func fmtPath(path string, check bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return fmtBundle(path, check)
+	}
+
+	fsys := os.DirFS(path)
+	saver, err := comply.DetectSaver(fsys)
+	if err != nil {
+		return nil, err
+	}
+	files, err := saver.NonCanonicalFiles(fsys)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	for i, f := range files {
+		files[i] = filepath.Join(path, filepath.FromSlash(f))
+	}
+	if check {
+		return files, nil
+	}
+	cf, err := comply.LoadFrameworkFromDir(path)
+	if err != nil {
+		return nil, err
+	}
+	return files, saver.SaveFrameworkToDir(cf, path)
+}
+
+func fmtBundle(path string, check bool) ([]string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return nil, fmt.Errorf("zip archives cannot be formatted; convert them to a directory first")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cf, err := comply.LoadBundle(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := comply.MarshalBundle(cf, comply.FormatFromPath(path), data)
+	if err != nil || bytes.Equal(data, out) {
+		return nil, err
+	}
+	if check {
+		return []string{path}, nil
+	}
+	return []string{path}, comply.WriteFileAtomic(path, out)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdGaps(args []string) {
+	fs := flag.NewFlagSet("gaps", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	solutionID := fs.String("solution", "", "Solution ID")
+	jurisdictionID := fs.String("jurisdiction", "", "Target jurisdiction ID")
+	profilePath := fs.String("profile", "", "Organization profile JSON file (optional)")
+	format := fs.String("format", "table", "Output format (table, json, markdown)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if *solutionID == "" || *jurisdictionID == "" {
+		fmt.Fprintln(os.Stderr, "Error: -solution and -jurisdiction are required")
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	var profile *comply.OrganizationProfile
+	if *profilePath != "" {
+		profile, err = comply.LoadOrganizationProfile(*profilePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	result, err := cf.GapAnalysis(*solutionID, *jurisdictionID, profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "json":
+		outputJSON(result)
+	case "markdown", "md":
+		fmt.Print(result.Markdown())
+	default:
+		printGaps(result)
+	}
+}
+
+func printGaps(result *comply.GapAnalysisResult) {
+	fmt.Printf("Gap analysis: %s in %s\n", result.SolutionID, result.JurisdictionID)
+	if result.Profile != nil && result.Profile.Name != "" {
+		fmt.Printf("Profile: %s\n", result.Profile.Name)
+	}
+	fmt.Printf("Applicable: %d  Compliant: %d  Gaps: %d\n", result.Applicable, result.Compliant, len(result.Gaps))
+
+	for _, z := range result.Zones {
+		fmt.Printf("Zone: %s (%s", z.Zone, z.DataCategory)
+		if z.EntityType != "" {
+			fmt.Printf(", %s", z.EntityType)
+		}
+		fmt.Printf(") %s\n", z.Rationale)
+	}
+	fmt.Println()
+
+	if len(result.Gaps) == 0 {
+		fmt.Println("No gaps found.")
+		return
+	}
+
+	fmt.Printf("%-9s %-22s %-20s %-14s %-10s %s\n", "SEVERITY", "REQUIREMENT", "REGULATION", "STATUS", "ETA", "CONDITIONS")
+	fmt.Println("----------------------------------------------------------------------------------------------------")
+	for _, g := range result.Gaps {
+		eta := g.ETA
+		if eta == "" {
+			eta = "-"
+		}
+		fmt.Printf("%-9s %-22s %-20s %-14s %-10s %s\n", g.Severity, g.RequirementID, g.RegulationID, g.Status(), eta, g.Conditions)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	solution := fs.String("solution", "", "Only changes to this solution")
+	requirement := fs.String("requirement", "", "Only changes to this requirement")
+	jurisdiction := fs.String("jurisdiction", "", "Only changes in this jurisdiction")
+	since := fs.String("since", "", "Only changes dated on or after this date (YYYY-MM-DD) or month (YYYY-MM)")
+	format := fs.String("format", "text", "Output format (text, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: comply history [-solution <id>] [-requirement <id>] [-jurisdiction <id>] [-since <yyyy-mm-dd>] [-format text|json] <history-file|dir>")
+		os.Exit(1)
+	}
+
+	path := fs.Arg(0)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, comply.HistoryFileName)
+	}
+	history, err := comply.LoadMappingHistory(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries := history.Query(comply.HistoryQuery{
+		SolutionID:     *solution,
+		RequirementID:  *requirement,
+		JurisdictionID: *jurisdiction,
+		Since:          *since,
+	})
+	if *format == "json" {
+		outputJSON(entries)
+		return
+	}
+	fmt.Print(comply.HistoryText(entries))
+}
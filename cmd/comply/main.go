@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -30,6 +31,32 @@ func main() {
 		cmdCoverage(os.Args[2:])
 	case "import-research":
 		cmdImportResearch(os.Args[2:])
+	case "applicable":
+		cmdApplicable(os.Args[2:])
+	case "zones":
+		cmdZones(os.Args[2:])
+	case "sovereignty":
+		cmdSovereignty(os.Args[2:])
+	case "gaps":
+		cmdGaps(os.Args[2:])
+	case "recommend":
+		cmdRecommend(os.Args[2:])
+	case "diff":
+		cmdDiff(os.Args[2:])
+	case "changelog":
+		cmdChangelog(os.Args[2:])
+	case "convert":
+		cmdConvert(os.Args[2:])
+	case "fmt":
+		cmdFmt(os.Args[2:])
+	case "migrate":
+		cmdMigrate(os.Args[2:])
+	case "schema":
+		cmdSchema(os.Args[2:])
+	case "conflicts":
+		cmdConflicts(os.Args[2:])
+	case "history":
+		cmdHistory(os.Args[2:])
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -52,14 +79,47 @@ Commands:
   validate        Validate JSON files in a directory
   coverage        Analyze mapping coverage and data completeness
   import-research Convert research findings JSON to mappings format
+  applicable      List regulations and requirements that apply to an organization profile
+  zones           Derive compliance zones from a rule set and compare with hand-entered zones
+  sovereignty     Evaluate solution ownership and extraterritorial exposure against a sovereignty regime
+  gaps            List applicable requirements a solution does not fully meet in a jurisdiction
+  recommend       Rank solutions for a workload with their blocking gaps
+  diff            Show semantic changes between two framework directories
+  changelog       Build a data changelog entry from two framework directories
+  convert         Convert a framework between JSON, YAML, bundle and zip layouts
+  fmt             Rewrite framework files in canonical form, or check them with -check
+  migrate         Upgrade a framework directory to the current schema version
+  schema          Print or write the JSON Schemas of the framework files
+  conflicts       Find cells where mappings or research findings give contradictory verdicts
+  history         Show the recorded changes to mapping cells and the research behind them
 
 Examples:
   comply load ./examples/minimal
   comply list -dir ./examples/minimal -type regulations
   comply query -dir ./examples/minimal -solution cloud-provider-a
   comply validate ./examples/minimal
+  comply validate -format json ./examples/minimal
+  comply validate -strict ./web/data
+  comply validate -require-extension mapping.owner ./web/data
+  comply validate -schema ./web/data
   comply coverage -dir ./examples/minimal
-  comply import-research -input research.json -output mappings-new.json`)
+  comply coverage -dir ./examples/minimal -jurisdiction EU -severity critical,high
+  comply import-research -input research.json -output mappings-new.json
+  comply applicable -dir ./web/data -profile ./examples/profiles/fr-energy-operator.json
+  comply zones -dir ./web/data -rules ./examples/rules/zone-rules.json -disagreements
+  comply sovereignty -dir ./web/data -regime eucs-high-plus
+  comply gaps -dir ./web/data -solution aws-commercial -jurisdiction FR -profile ./examples/profiles/fr-energy-operator.json
+  comply recommend -dir ./web/data -jurisdiction FR -data-category essential-data -entity-type essential-entity
+  comply diff -format markdown ./old ./web/data
+  comply changelog -file DATA_CHANGELOG.json -markdown DATA_CHANGELOG.md ./old ./web/data
+  comply convert -to yaml -o ./data-yaml ./web/data
+  comply fmt -check ./examples/minimal
+  comply migrate -dry-run ./old-data
+  comply schema -o ./schema
+  comply conflicts -exit-code ./web/data
+  comply conflicts -research research.json -aliases schema/control-mapping.json ./web/data
+  comply history -solution aws-commercial -requirement CTL-LEGAL-001 -jurisdiction FR ./web/data
+  comply history -since 2026-01-01 ./web/data/history.jsonl`)
 }
 
 func cmdLoad(args []string) {
@@ -69,7 +129,7 @@ func cmdLoad(args []string) {
 	}
 	dir := args[0]
 
-	cf, err := comply.LoadFrameworkFromDir(dir)
+	cf, err := comply.LoadFramework(dir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
 		os.Exit(1)
@@ -91,7 +151,7 @@ func cmdLoad(args []string) {
 
 func cmdList(args []string) {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
-	dir := fs.String("dir", ".", "Directory containing JSON files")
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
 	itemType := fs.String("type", "", "Type to list (jurisdictions, regulations, requirements, solutions, mappings, zones, enforcement)")
 	format := fs.String("format", "table", "Output format (table, json)")
 	if err := fs.Parse(args); err != nil {
@@ -103,7 +163,7 @@ func cmdList(args []string) {
 		os.Exit(1)
 	}
 
-	cf, err := comply.LoadFrameworkFromDir(*dir)
+	cf, err := comply.LoadFramework(*dir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
 		os.Exit(1)
@@ -132,10 +192,11 @@ func cmdList(args []string) {
 
 func cmdQuery(args []string) {
 	fs := flag.NewFlagSet("query", flag.ExitOnError)
-	dir := fs.String("dir", ".", "Directory containing JSON files")
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
 	solutionID := fs.String("solution", "", "Solution ID to query")
 	requirementID := fs.String("requirement", "", "Requirement ID to query")
-	jurisdictionID := fs.String("jurisdiction", "", "Filter by jurisdiction ID")
+	jurisdictionID := fs.String("jurisdiction", "", "Filter by jurisdiction ID (includes mappings inherited from parent jurisdictions)")
+	exact := fs.Bool("exact", false, "Match -jurisdiction exactly, without inheritance")
 	format := fs.String("format", "table", "Output format (table, json)")
 	if err := fs.Parse(args); err != nil {
 		os.Exit(1)
@@ -146,28 +207,32 @@ func cmdQuery(args []string) {
 		os.Exit(1)
 	}
 
-	cf, err := comply.LoadFrameworkFromDir(*dir)
+	cf, err := comply.LoadFramework(*dir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
 		os.Exit(1)
 	}
 
-	var mappings []comply.RequirementMapping
-	if *solutionID != "" {
-		mappings = cf.GetMappingsForSolution(*solutionID)
-	} else {
-		mappings = cf.GetMappingsForRequirement(*requirementID)
-	}
-
-	// Filter by jurisdiction if specified
-	if *jurisdictionID != "" {
-		var filtered []comply.RequirementMapping
-		for _, m := range mappings {
-			if len(m.JurisdictionIDs) == 0 || slices.Contains(m.JurisdictionIDs, *jurisdictionID) {
-				filtered = append(filtered, m)
+	var mappings []comply.ResolvedMapping
+	switch {
+	case *jurisdictionID != "" && !*exact:
+		for _, m := range cf.EffectiveMappings(*jurisdictionID) {
+			if (*solutionID != "" && m.SolutionID == *solutionID) || (*solutionID == "" && m.RequirementID == *requirementID) {
+				mappings = append(mappings, m)
+			}
+		}
+	default:
+		var raw []comply.RequirementMapping
+		if *solutionID != "" {
+			raw = cf.GetMappingsForSolution(*solutionID)
+		} else {
+			raw = cf.GetMappingsForRequirement(*requirementID)
+		}
+		for _, m := range raw {
+			if *jurisdictionID == "" || len(m.JurisdictionIDs) == 0 || slices.Contains(m.JurisdictionIDs, *jurisdictionID) {
+				mappings = append(mappings, comply.ResolvedMapping{RequirementMapping: m, ResolvedFor: *jurisdictionID})
 			}
 		}
-		mappings = filtered
 	}
 
 	if *format == "json" {
@@ -187,6 +252,9 @@ func cmdQuery(args []string) {
 		if len(m.JurisdictionIDs) > 0 {
 			fmt.Printf("  Jurisdictions: %s\n", strings.Join(m.JurisdictionIDs, ", "))
 		}
+		if m.Inherited {
+			fmt.Printf("  Inherited:   from %s\n", m.SourceJurisdictionID)
+		}
 		if m.Notes != "" {
 			fmt.Printf("  Notes:       %s\n", m.Notes)
 		}
@@ -195,76 +263,104 @@ func cmdQuery(args []string) {
 }
 
 func cmdValidate(args []string) {
-	if len(args) < 1 {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	format := fs.String("format", "table", "Output format (table, json)")
+	strict := fs.Bool("strict", false, "Report every invalid enum value with its file and JSON path")
+	lenient := fs.Bool("lenient", false, "Drop invalid enum values with a warning instead of failing")
+	requireExt := fs.String("require-extension", "", "Comma-separated extension fields every entity of a kind must set, e.g. mapping.owner,requirement.ticket")
+	schema := fs.Bool("schema", false, "Also check every file against the generated JSON Schemas, reporting JSON pointers")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if fs.NArg() < 1 {
 		fmt.Fprintln(os.Stderr, "Error: directory path required")
 		os.Exit(1)
 	}
-	dir := args[0]
-
-	cf, err := comply.LoadFrameworkFromDir(dir)
+	dir := fs.Arg(0)
+	validators, err := comply.ParseRequiredExtensions(splitList(*requireExt))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Validation failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	var errors []string
-
-	// Validate regulation references
-	regulationIDs := make(map[string]bool)
-	for _, r := range cf.Regulations {
-		regulationIDs[r.ID] = true
+	if *schema {
+		issues, err := comply.ValidateSchemas(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Schema validation failed: %v\n", err)
+			os.Exit(1)
+		}
+		if len(issues) > 0 {
+			if *format == "json" {
+				outputJSON(map[string]any{"schemaIssues": issues})
+			} else {
+				fmt.Println("Schema violations found:")
+				for _, issue := range issues {
+					fmt.Printf("  - %s\n", issue)
+				}
+			}
+			os.Exit(1)
+		}
 	}
 
-	for _, req := range cf.Requirements {
-		if req.RegulationID != "" && !regulationIDs[req.RegulationID] {
-			errors = append(errors, fmt.Sprintf("Requirement %s references unknown regulation: %s", req.ID, req.RegulationID))
+	loader := &comply.Loader{}
+	switch {
+	case *strict:
+		loader.Mode = comply.DecodeStrict
+	case *lenient:
+		loader.Mode = comply.DecodeLenient
+	}
+	cf, err := loader.LoadFramework(dir)
+	var decodeErr *comply.DecodeError
+	if errors.As(err, &decodeErr) {
+		if *format == "json" {
+			outputJSON(decodeErr)
+		} else {
+			fmt.Println("Invalid values found:")
+			for _, issue := range decodeErr.Issues {
+				fmt.Printf("  - %s\n", issue)
+			}
 		}
+		os.Exit(1)
 	}
-
-	// Validate solution references
-	solutionIDs := make(map[string]bool)
-	for _, s := range cf.Solutions {
-		solutionIDs[s.ID] = true
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Validation failed: %v\n", err)
+		os.Exit(1)
 	}
-
-	requirementIDs := make(map[string]bool)
-	for _, r := range cf.Requirements {
-		requirementIDs[r.ID] = true
+	for _, issue := range loader.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: dropped %s\n", issue)
 	}
 
-	for _, m := range cf.Mappings {
-		if !solutionIDs[m.SolutionID] {
-			errors = append(errors, fmt.Sprintf("Mapping %s references unknown solution: %s", m.ID, m.SolutionID))
-		}
-		if !requirementIDs[m.RequirementID] {
-			errors = append(errors, fmt.Sprintf("Mapping %s references unknown requirement: %s", m.ID, m.RequirementID))
-		}
-	}
+	report := cf.ValidateWith(validators...)
 
-	// Validate zone assignments
-	jurisdictionIDs := make(map[string]bool)
-	for _, j := range cf.Jurisdictions {
-		jurisdictionIDs[j.ID] = true
+	if *format == "json" {
+		outputJSON(report)
+	} else {
+		printValidationReport(report)
 	}
-
-	for _, za := range cf.ZoneAssignments {
-		if !solutionIDs[za.SolutionID] {
-			errors = append(errors, fmt.Sprintf("Zone assignment %s references unknown solution: %s", za.ID, za.SolutionID))
-		}
-		if !jurisdictionIDs[za.JurisdictionID] {
-			errors = append(errors, fmt.Sprintf("Zone assignment %s references unknown jurisdiction: %s", za.ID, za.JurisdictionID))
-		}
+	if !report.Valid() {
+		os.Exit(1)
 	}
+}
+
+func printValidationReport(report *comply.ValidationReport) {
+	errs := report.Errors()
+	warnings := report.Warnings()
 
-	if len(errors) > 0 {
+	if len(errs) > 0 {
 		fmt.Println("Validation errors found:")
-		for _, e := range errors {
-			fmt.Printf("  - %s\n", e)
+		for _, d := range errs {
+			fmt.Printf("  - %s\n", d)
 		}
-		os.Exit(1)
 	}
-
-	fmt.Println("Validation passed!")
+	if len(warnings) > 0 {
+		fmt.Println("Validation warnings:")
+		for _, d := range warnings {
+			fmt.Printf("  - %s\n", d)
+		}
+	}
+	if len(errs) == 0 {
+		fmt.Println("Validation passed!")
+	}
 }
 
 func listJurisdictions(cf *comply.ComplianceFramework, format string) {
@@ -353,19 +449,32 @@ func listEnforcement(cf *comply.ComplianceFramework, format string) {
 
 func cmdCoverage(args []string) {
 	fs := flag.NewFlagSet("coverage", flag.ExitOnError)
-	dir := fs.String("dir", ".", "Directory containing JSON files")
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	jurisdictions := fs.String("jurisdiction", "", "Comma-separated jurisdiction IDs (default: all in framework)")
+	regulations := fs.String("regulation", "", "Comma-separated regulation IDs to include")
+	categories := fs.String("category", "", "Comma-separated requirement categories to include")
+	severities := fs.String("severity", "", "Comma-separated requirement severities to include")
 	format := fs.String("format", "table", "Output format (table, json)")
 	if err := fs.Parse(args); err != nil {
 		os.Exit(1)
 	}
 
-	cf, err := comply.LoadFrameworkFromDir(*dir)
+	cf, err := comply.LoadFramework(*dir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
 		os.Exit(1)
 	}
 
-	stats := calculateCoverage(cf)
+	opts := &comply.CoverageOptions{
+		JurisdictionIDs: splitList(*jurisdictions),
+		RegulationIDs:   splitList(*regulations),
+		Categories:      splitList(*categories),
+	}
+	for _, sev := range splitList(*severities) {
+		opts.Severities = append(opts.Severities, comply.RequirementSeverity(sev))
+	}
+
+	stats := cf.Coverage(opts)
 
 	if *format == "json" {
 		outputJSON(stats)
@@ -375,113 +484,7 @@ func cmdCoverage(args []string) {
 	printCoverageReport(stats)
 }
 
-// CoverageStats holds coverage statistics for the framework.
-type CoverageStats struct {
-	TotalRequirements   int                       `json:"totalRequirements"`
-	TotalSolutions      int                       `json:"totalSolutions"`
-	TotalMappings       int                       `json:"totalMappings"`
-	MappingsWithEvidence int                      `json:"mappingsWithEvidence"`
-	EvidencePercent     float64                   `json:"evidencePercent"`
-	ByJurisdiction      map[string]JurisdictionCoverage `json:"byJurisdiction"`
-}
-
-// JurisdictionCoverage holds coverage stats for a specific jurisdiction.
-type JurisdictionCoverage struct {
-	JurisdictionID   string  `json:"jurisdictionId"`
-	SolutionCount    int     `json:"solutionCount"`
-	MaxCells         int     `json:"maxCells"`
-	CoveredCells     int     `json:"coveredCells"`
-	CoveragePercent  float64 `json:"coveragePercent"`
-	WithEvidence     int     `json:"withEvidence"`
-	EvidencePercent  float64 `json:"evidencePercent"`
-	MissingCells     int     `json:"missingCells"`
-}
-
-func calculateCoverage(cf *comply.ComplianceFramework) *CoverageStats {
-	stats := &CoverageStats{
-		TotalRequirements: len(cf.Requirements),
-		TotalSolutions:    len(cf.Solutions),
-		TotalMappings:     len(cf.Mappings),
-		ByJurisdiction:    make(map[string]JurisdictionCoverage),
-	}
-
-	// Count mappings with evidence
-	for _, m := range cf.Mappings {
-		if len(m.Evidence) > 0 {
-			stats.MappingsWithEvidence++
-		}
-	}
-	if stats.TotalMappings > 0 {
-		stats.EvidencePercent = float64(stats.MappingsWithEvidence) / float64(stats.TotalMappings) * 100
-	}
-
-	// Build solution-to-jurisdictions map
-	solJurisdictions := make(map[string][]string)
-	for _, s := range cf.Solutions {
-		solJurisdictions[s.ID] = s.JurisdictionIDs
-	}
-
-	// Track covered cells per jurisdiction
-	type cellKey struct {
-		reqID string
-		solID string
-	}
-	coveredByJur := make(map[string]map[cellKey]bool)
-	evidenceByJur := make(map[string]map[cellKey]bool)
-
-	for _, m := range cf.Mappings {
-		hasEvidence := len(m.Evidence) > 0
-		for _, jurID := range m.JurisdictionIDs {
-			if coveredByJur[jurID] == nil {
-				coveredByJur[jurID] = make(map[cellKey]bool)
-				evidenceByJur[jurID] = make(map[cellKey]bool)
-			}
-			key := cellKey{m.RequirementID, m.SolutionID}
-			coveredByJur[jurID][key] = true
-			if hasEvidence {
-				evidenceByJur[jurID][key] = true
-			}
-		}
-	}
-
-	// Calculate stats per jurisdiction
-	mainJurisdictions := []string{"EU", "FR", "DE", "UK", "KSA"}
-	for _, jurID := range mainJurisdictions {
-		// Count solutions available in this jurisdiction
-		var solsInJur []string
-		for _, s := range cf.Solutions {
-			if slices.Contains(s.JurisdictionIDs, jurID) {
-				solsInJur = append(solsInJur, s.ID)
-			}
-		}
-
-		maxCells := len(cf.Requirements) * len(solsInJur)
-		coveredCells := len(coveredByJur[jurID])
-		withEvidence := len(evidenceByJur[jurID])
-
-		jc := JurisdictionCoverage{
-			JurisdictionID:  jurID,
-			SolutionCount:   len(solsInJur),
-			MaxCells:        maxCells,
-			CoveredCells:    coveredCells,
-			MissingCells:    maxCells - coveredCells,
-			WithEvidence:    withEvidence,
-		}
-
-		if maxCells > 0 {
-			jc.CoveragePercent = float64(coveredCells) / float64(maxCells) * 100
-		}
-		if coveredCells > 0 {
-			jc.EvidencePercent = float64(withEvidence) / float64(coveredCells) * 100
-		}
-
-		stats.ByJurisdiction[jurID] = jc
-	}
-
-	return stats
-}
-
-func printCoverageReport(stats *CoverageStats) {
+func printCoverageReport(stats *comply.CoverageStats) {
 	fmt.Println("=== Compliance Framework Coverage Report ===")
 	fmt.Println()
 	fmt.Println("Summary:")
@@ -493,52 +496,32 @@ func printCoverageReport(stats *CoverageStats) {
 
 	fmt.Println("Coverage by Jurisdiction:")
 	fmt.Println()
-	fmt.Printf("%-8s %8s %8s %8s %10s %10s %10s\n",
-		"JUR", "SOLS", "MAX", "COVERED", "COVERAGE%", "EVIDENCE", "EVIDENCE%")
-	fmt.Println(strings.Repeat("-", 72))
-
-	totalMax := 0
-	totalCovered := 0
-	totalEvidence := 0
-
-	jurisdictionOrder := []string{"EU", "FR", "DE", "UK", "KSA"}
-	for _, jurID := range jurisdictionOrder {
-		jc, ok := stats.ByJurisdiction[jurID]
-		if !ok {
-			continue
-		}
-		fmt.Printf("%-8s %8d %8d %8d %9.1f%% %10d %9.1f%%\n",
+	fmt.Printf("%-8s %8s %8s %8s %10s %10s %10s %10s\n",
+		"JUR", "SOLS", "MAX", "COVERED", "COVERAGE%", "WEIGHTED%", "EVIDENCE", "EVIDENCE%")
+	fmt.Println(strings.Repeat("-", 83))
+
+	for _, jurID := range stats.JurisdictionIDs {
+		jc := stats.ByJurisdiction[jurID]
+		fmt.Printf("%-8s %8d %8d %8d %9.1f%% %9.1f%% %10d %9.1f%%\n",
 			jc.JurisdictionID,
 			jc.SolutionCount,
 			jc.MaxCells,
 			jc.CoveredCells,
 			jc.CoveragePercent,
+			jc.WeightedCoveragePercent,
 			jc.WithEvidence,
 			jc.EvidencePercent)
-		totalMax += jc.MaxCells
-		totalCovered += jc.CoveredCells
-		totalEvidence += jc.WithEvidence
 	}
 
-	fmt.Println(strings.Repeat("-", 72))
-	overallCoverage := float64(0)
-	overallEvidence := float64(0)
-	if totalMax > 0 {
-		overallCoverage = float64(totalCovered) / float64(totalMax) * 100
-	}
-	if totalCovered > 0 {
-		overallEvidence = float64(totalEvidence) / float64(totalCovered) * 100
-	}
-	fmt.Printf("%-8s %8s %8d %8d %9.1f%% %10d %9.1f%%\n",
-		"TOTAL", "-", totalMax, totalCovered, overallCoverage, totalEvidence, overallEvidence)
+	fmt.Println(strings.Repeat("-", 83))
+	fmt.Printf("%-8s %8s %8d %8d %9.1f%% %9.1f%% %10d %9.1f%%\n",
+		"TOTAL", "-", stats.MaxCells, stats.CoveredCells, stats.CoveragePercent,
+		stats.WeightedCoveragePercent, stats.CellsWithEvidence, stats.CellEvidencePercent)
 	fmt.Println()
 
 	fmt.Println("Gap Analysis:")
-	for _, jurID := range jurisdictionOrder {
-		jc, ok := stats.ByJurisdiction[jurID]
-		if !ok {
-			continue
-		}
+	for _, jurID := range stats.JurisdictionIDs {
+		jc := stats.ByJurisdiction[jurID]
 		fmt.Printf("  %s: %d cells missing (%.1f%% gap)\n",
 			jurID, jc.MissingCells, 100-jc.CoveragePercent)
 	}
@@ -552,6 +535,9 @@ func cmdImportResearch(args []string) {
 	analyze := fs.Bool("analyze", false, "Print analysis report instead of mappings")
 	validate := fs.Bool("validate", false, "Validate research against framework")
 	merge := fs.Bool("merge", false, "Merge with existing mappings (requires -dir)")
+	historyFile := fs.String("history", "", "Append the cells changed by -merge to this mapping history file once -output is written, e.g. ./web/data/history.jsonl")
+	aliasFiles := fs.String("aliases", "", "Comma-separated control alias files, e.g. schema/control-mapping.json")
+	idScheme := fs.String("id-scheme", comply.MappingIDSchemeReadable, "Scheme deriving new mapping IDs ("+strings.Join(comply.MappingIDSchemeNames(), ", ")+")")
 	format := fs.String("format", "table", "Output format (table, json)")
 	if err := fs.Parse(args); err != nil {
 		os.Exit(1)
@@ -561,6 +547,10 @@ func cmdImportResearch(args []string) {
 		fmt.Fprintln(os.Stderr, "Error: -input is required")
 		os.Exit(1)
 	}
+	if *historyFile != "" && (!*merge || *outputFile == "") {
+		fmt.Fprintln(os.Stderr, "Error: -history requires -merge and -output, so that only written merges are recorded")
+		os.Exit(1)
+	}
 
 	// Load research file using the comply package
 	research, err := comply.LoadResearchInput(*inputFile)
@@ -568,6 +558,33 @@ func cmdImportResearch(args []string) {
 		fmt.Fprintf(os.Stderr, "Error loading research file: %v\n", err)
 		os.Exit(1)
 	}
+	research.IDScheme, err = comply.LookupMappingIDScheme(*idScheme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Load framework if specified for validation or merge
+	var framework *comply.ComplianceFramework
+	if *frameworkDir != "" {
+		framework, err = comply.LoadFramework(*frameworkDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Rewrite control aliases to canonical requirement IDs
+	var aliases *comply.ControlAliases
+	if *aliasFiles != "" {
+		aliases, err = comply.LoadControlAliases(splitList(*aliasFiles)...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	resolution := research.ResolveControls(aliases, framework)
+	printControlResolution(resolution, !*validate)
 
 	// If analyzing, just print the analysis report
 	if *analyze {
@@ -580,16 +597,6 @@ func cmdImportResearch(args []string) {
 		return
 	}
 
-	// Load framework if specified for validation or merge
-	var framework *comply.ComplianceFramework
-	if *frameworkDir != "" {
-		framework, err = comply.LoadFrameworkFromDir(*frameworkDir)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
-			os.Exit(1)
-		}
-	}
-
 	// Validate if requested
 	if *validate {
 		if framework == nil {
@@ -614,26 +621,12 @@ func cmdImportResearch(args []string) {
 			fmt.Fprintln(os.Stderr, "Error: -dir is required for merge")
 			os.Exit(1)
 		}
-		newMappings, updatedMappings, unchangedMappings := research.MergeWithMappings(framework.Mappings)
-
-		fmt.Fprintf(os.Stderr, "Merge Summary:\n")
-		fmt.Fprintf(os.Stderr, "  New mappings:       %d\n", len(newMappings))
-		fmt.Fprintf(os.Stderr, "  Updated mappings:   %d\n", len(updatedMappings))
-		fmt.Fprintf(os.Stderr, "  Unchanged mappings: %d\n", len(unchangedMappings))
-
-		// Combine all mappings
-		allMappings := make([]comply.RequirementMapping, 0, len(newMappings)+len(updatedMappings)+len(unchangedMappings))
-		allMappings = append(allMappings, unchangedMappings...)
-		allMappings = append(allMappings, updatedMappings...)
-		allMappings = append(allMappings, newMappings...)
+		result := research.Merge(framework.Mappings)
+		fmt.Fprint(os.Stderr, result.Text())
+		allMappings := result.Mappings()
 
 		if *outputFile != "" {
-			output, err := json.MarshalIndent(allMappings, "", "  ")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding mappings: %v\n", err)
-				os.Exit(1)
-			}
-			if err := os.WriteFile(*outputFile, output, 0644); err != nil {
+			if err := comply.WriteJSON(*outputFile, allMappings, true); err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
 				os.Exit(1)
 			}
@@ -641,20 +634,27 @@ func cmdImportResearch(args []string) {
 		} else if *format == "json" {
 			outputJSON(allMappings)
 		}
+
+		if *historyFile != "" {
+			n, err := comply.AppendMappingHistory(*historyFile, result.History)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing history: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Appended %d history entries to %s\n", n, *historyFile)
+		}
 		return
 	}
 
 	// Default: convert to mappings
 	mappings := research.ToMappings()
+	for _, c := range research.MappingIDCollisions() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", c)
+	}
 
 	// Output
 	if *outputFile != "" {
-		output, err := json.MarshalIndent(mappings, "", "  ")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding mappings: %v\n", err)
-			os.Exit(1)
-		}
-		if err := os.WriteFile(*outputFile, output, 0644); err != nil {
+		if err := comply.WriteJSON(*outputFile, mappings, true); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
 			os.Exit(1)
 		}
@@ -672,6 +672,32 @@ func cmdImportResearch(args []string) {
 	}
 }
 
+// printControlResolution prints the rewritten control aliases and, when
+// unresolved is true, the controls that match no requirement, to stderr.
+func printControlResolution(res *comply.ControlResolution, unresolved bool) {
+	if len(res.Resolved) > 0 {
+		fmt.Fprintf(os.Stderr, "Resolved %d control aliases\n", len(res.Resolved))
+	}
+	if !unresolved || len(res.Unresolved) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Unresolved controls (%d):\n", len(res.Unresolved))
+	for _, u := range res.Unresolved {
+		fmt.Fprintf(os.Stderr, "  [%d] %s", u.Index, u.ControlID)
+		if u.ControlName != "" {
+			fmt.Fprintf(os.Stderr, " %q", u.ControlName)
+		}
+		if len(u.Suggestions) > 0 {
+			names := make([]string, len(u.Suggestions))
+			for i, sug := range u.Suggestions {
+				names[i] = sug.String()
+			}
+			fmt.Fprintf(os.Stderr, ": did you mean %s?", strings.Join(names, ", "))
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
 func printValidationResult(result *comply.ValidationResult) {
 	if result.Valid {
 		fmt.Println("Validation PASSED")
@@ -703,6 +729,11 @@ func printValidationResult(result *comply.ValidationResult) {
 		for msg, count := range warningCounts {
 			fmt.Printf("  %s (x%d)\n", msg, count)
 		}
+		for _, w := range result.Warnings {
+			if len(w.Suggestions) > 0 {
+				fmt.Printf("  [%d] %s %s: did you mean %s?\n", w.Index, w.Field, w.Value, strings.Join(w.Suggestions, ", "))
+			}
+		}
 	}
 }
 
@@ -714,3 +745,14 @@ func outputJSON(v any) {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(v string) []string {
+	var result []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
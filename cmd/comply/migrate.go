@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	output := fs.String("o", "", "Output directory (default: upgrade the input directory in place)")
+	dryRun := fs.Bool("dry-run", false, "Print the report without writing files")
+	format := fs.String("format", "table", "Output format (table, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: comply migrate [-o <dir>] [-dry-run] [-format table|json] <dir>")
+		os.Exit(1)
+	}
+	input := fs.Arg(0)
+	if info, err := os.Stat(input); err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Error: %s is not a framework directory; convert bundles and archives to a directory first\n", input)
+		os.Exit(1)
+	}
+
+	fsys := os.DirFS(input)
+	saver, err := comply.DetectSaver(fsys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", input, err)
+		os.Exit(1)
+	}
+	cf, report, err := comply.MigrateFS(fsys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error migrating %s: %v\n", input, err)
+		os.Exit(1)
+	}
+
+	if *format == "json" {
+		outputJSON(report)
+	} else {
+		fmt.Print(report.Text())
+	}
+	if *dryRun || (*output == "" && report.FromVersion == report.ToVersion) {
+		return
+	}
+
+	dir := *output
+	if dir == "" {
+		dir = input
+		saver.Staged = true
+	}
+	if err := saver.SaveFrameworkToDir(cf, dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+	if *format != "json" {
+		fmt.Printf("\nWrote %s\n", dir)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdRecommend(args []string) {
+	fs := flag.NewFlagSet("recommend", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	workloadPath := fs.String("workload", "", "Workload JSON file (alternative to the workload flags)")
+	jurisdictions := fs.String("jurisdiction", "", "Comma-separated jurisdiction IDs")
+	dataCategory := fs.String("data-category", "", "Data category (e.g., essential-data)")
+	entityType := fs.String("entity-type", "", "Entity type (e.g., essential-entity)")
+	sector := fs.String("sector", "", "Sector (e.g., energy)")
+	weightsPath := fs.String("weights", "", "Scoring weights JSON file")
+	top := fs.Int("top", 0, "Only show the top N solutions")
+	maxGaps := fs.Int("gaps", 3, "Blocking gaps to show per solution (0 for all)")
+	format := fs.String("format", "table", "Output format (table, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	var workload *comply.Workload
+	if *workloadPath != "" {
+		var err error
+		workload, err = comply.LoadWorkload(*workloadPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading workload: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		workload = &comply.Workload{
+			JurisdictionIDs: splitList(*jurisdictions),
+			DataCategory:    *dataCategory,
+			EntityType:      *entityType,
+			Sector:          *sector,
+		}
+	}
+	if len(workload.JurisdictionIDs) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: -jurisdiction or -workload is required")
+		os.Exit(1)
+	}
+
+	weights := comply.DefaultRecommendationWeights()
+	if *weightsPath != "" {
+		var err error
+		weights, err = comply.LoadRecommendationWeights(*weightsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading weights: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	cf, err := comply.LoadFramework(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := cf.Recommend(workload, weights)
+	if *top > 0 && *top < len(result.Recommendations) {
+		result.Recommendations = result.Recommendations[:*top]
+	}
+
+	if *format == "json" {
+		outputJSON(result)
+		return
+	}
+
+	printRecommendations(result, *maxGaps)
+}
+
+func printRecommendations(result *comply.RecommendationResult, maxGaps int) {
+	w := result.Workload
+	fmt.Printf("Recommendations for %s", strings.Join(w.JurisdictionIDs, ", "))
+	for _, v := range []string{w.DataCategory, w.EntityType, w.Sector} {
+		if v != "" {
+			fmt.Printf(" / %s", v)
+		}
+	}
+	fmt.Print("\n\n")
+
+	fmt.Printf("%-4s %-22s %6s %6s %6s %6s %6s  %-7s %s\n", "RANK", "SOLUTION", "SCORE", "ZFIT", "COMPL", "EVID", "CONF", "ZONE", "STATUS")
+	fmt.Println("------------------------------------------------------------------------------------------")
+	for _, r := range result.Recommendations {
+		status := "ok"
+		if r.Blocked {
+			status = "blocked"
+		}
+		zone := string(r.Zone)
+		if zone == "" {
+			zone = "-"
+		}
+		fmt.Printf("%-4d %-22s %6.1f %6.1f %6.1f %6.1f %6.1f  %-7s %s\n",
+			r.Rank, r.SolutionID, r.Score, r.ZoneScore, r.ComplianceScore, r.EvidenceScore, r.ConfidenceScore, zone, status)
+
+		for _, reason := range r.BlockReasons {
+			fmt.Printf("       - %s\n", reason)
+		}
+		for i, g := range r.BlockingGaps {
+			if maxGaps > 0 && i == maxGaps {
+				fmt.Printf("       ... %d more blocking gaps\n", len(r.BlockingGaps)-maxGaps)
+				break
+			}
+			fmt.Printf("       %s %s %s (%s, %s)\n", g.JurisdictionID, g.RequirementID, g.Status(), g.Severity, g.RegulationID)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdSchema(args []string) {
+	fs := flag.NewFlagSet("schema", flag.ExitOnError)
+	output := fs.String("o", "", "Write every schema to <name>.schema.json in this directory")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: comply schema <name> | comply schema -o <dir>")
+			fmt.Fprintf(os.Stderr, "Schemas: %v\n", comply.SchemaNames())
+			os.Exit(1)
+		}
+		data, err := comply.GenerateSchema(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(data)
+		return
+	}
+
+	if err := os.MkdirAll(*output, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	for _, name := range comply.SchemaNames() {
+		data, err := comply.GenerateSchema(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		path := filepath.Join(*output, name+".schema.json")
+		if err := comply.WriteFileAtomic(path, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
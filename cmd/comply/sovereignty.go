@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdSovereignty(args []string) {
+	fs := flag.NewFlagSet("sovereignty", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	regimeID := fs.String("regime", "secnumcloud", "Sovereignty regime ID")
+	regimesPath := fs.String("regimes", "", "JSON file with additional sovereignty regimes")
+	solutionID := fs.String("solution", "", "Only evaluate this solution")
+	format := fs.String("format", "table", "Output format (table, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	regimes := comply.DefaultSovereigntyRegimes()
+	if *regimesPath != "" {
+		custom, err := comply.LoadSovereigntyRegimes(*regimesPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading regimes: %v\n", err)
+			os.Exit(1)
+		}
+		regimes = append(custom, regimes...)
+	}
+
+	var regime *comply.SovereigntyRegime
+	var ids []string
+	for i := range regimes {
+		ids = append(ids, regimes[i].ID)
+		if regime == nil && regimes[i].ID == *regimeID {
+			regime = &regimes[i]
+		}
+	}
+	if regime == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown regime %q (available: %s)\n", *regimeID, strings.Join(ids, ", "))
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	var results []comply.SovereigntyEvaluation
+	if *solutionID != "" {
+		sol := cf.GetSolution(*solutionID)
+		if sol == nil {
+			fmt.Fprintf(os.Stderr, "Error: unknown solution %q\n", *solutionID)
+			os.Exit(1)
+		}
+		results = append(results, *regime.Evaluate(sol))
+	} else {
+		results = cf.EvaluateSovereignty(regime)
+	}
+
+	if *format == "json" {
+		outputJSON(results)
+		return
+	}
+
+	fmt.Printf("Sovereignty evaluation: %s\n\n", regime.Name)
+	fmt.Printf("%-22s %-6s %-10s %-10s %-9s %s\n", "SOLUTION", "RESULT", "SINGLE", "COLLECTIVE", "EXPOSURE", "REASONS")
+	fmt.Println("------------------------------------------------------------------------------------------")
+	for _, r := range results {
+		result := "FAIL"
+		if r.Pass {
+			result = "PASS"
+		}
+		margins := make([]string, 2)
+		for i, name := range []string{comply.ThresholdSingleNonEU, comply.ThresholdCollectiveNonEU} {
+			margins[i] = "-"
+			if c := r.OwnershipCheck(name); c != nil {
+				margins[i] = fmt.Sprintf("%+.0f", c.Margin)
+			}
+		}
+		reasons := strings.Join(r.Exposure.Reasons, "; ")
+		if reasons == "" {
+			reasons = "-"
+		}
+		fmt.Printf("%-22s %-6s %-10s %-10s %-9s %s\n", r.SolutionID, result, margins[0], margins[1], r.Exposure.Verdict, reasons)
+	}
+	fmt.Println("\nSINGLE and COLLECTIVE show the margin to the non-EU ownership limits in percentage points.")
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	comply "github.com/grokify/go-comply"
+)
+
+func cmdZones(args []string) {
+	fs := flag.NewFlagSet("zones", flag.ExitOnError)
+	dir := fs.String("dir", ".", "Directory or .zip archive containing JSON files")
+	rulesPath := fs.String("rules", "", "Zone rule set JSON file")
+	onlyDisagreements := fs.Bool("disagreements", false, "Only show where computed zones disagree with hand-entered ones")
+	showNew := fs.Bool("new", false, "Also list computed zones that have no hand-entered assignment")
+	output := fs.String("output", "", "Write computed zone assignments to this JSON file")
+	format := fs.String("format", "table", "Output format (table, json)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if *rulesPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -rules is required")
+		os.Exit(1)
+	}
+
+	cf, err := comply.LoadFramework(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	rs, err := comply.LoadZoneRuleSet(*rulesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading rules: %v\n", err)
+		os.Exit(1)
+	}
+
+	result := cf.DeriveZones(rs)
+
+	if *output != "" {
+		if err := comply.WriteJSON(*output, result.ZoneAssignments(), true); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d zone assignments to %s\n", len(result.Assignments), *output)
+	}
+
+	if *format == "json" {
+		if *onlyDisagreements {
+			outputJSON(result.Disagreements)
+		} else {
+			outputJSON(result)
+		}
+		return
+	}
+
+	if !*onlyDisagreements {
+		fmt.Printf("%-22s %-5s %-24s %-18s %-7s %s\n", "SOLUTION", "JUR", "DATA CATEGORY", "ENTITY TYPE", "ZONE", "RULE")
+		fmt.Println("------------------------------------------------------------------------------------------------------")
+		for _, a := range result.Assignments {
+			fmt.Printf("%-22s %-5s %-24s %-18s %-7s %s\n", a.SolutionID, a.JurisdictionID, a.DataCategory, a.EntityType, a.Zone, a.RuleID)
+		}
+		fmt.Printf("\nComputed %d zone assignments\n\n", len(result.Assignments))
+	}
+
+	counts := make(map[comply.ZoneDisagreementKind]int)
+	for _, d := range result.Disagreements {
+		counts[d.Kind]++
+	}
+	fmt.Printf("Disagreements: %d mismatched, %d not computed, %d not hand-entered\n",
+		counts[comply.ZoneMismatch], counts[comply.ZoneNotComputed], counts[comply.ZoneNotAssigned])
+	for _, d := range result.Disagreements {
+		if d.Kind == comply.ZoneNotAssigned && !*showNew {
+			continue
+		}
+		fmt.Printf("  [%s] %s\n", d.Kind, d)
+	}
+	if counts[comply.ZoneNotAssigned] > 0 && !*showNew {
+		fmt.Println("  (use -new to list computed zones that are not hand-entered)")
+	}
+}
@@ -4,8 +4,9 @@ package comply
 type ComplianceFramework struct {
 	Name                   string                  `json:"name"`
 	Version                string                  `json:"version"`
+	SchemaVersion          int                     `json:"schemaVersion,omitempty"` // see comply.SchemaVersion
 	Description            string                  `json:"description,omitempty"`
-	LastUpdated            string                  `json:"lastUpdated,omitempty"`
+	LastUpdated            string                  `json:"lastUpdated,omitempty" jsonschema:"format=date"`
 	Jurisdictions          []Jurisdiction          `json:"jurisdictions,omitempty"`
 	Regulations            []Regulation            `json:"regulations,omitempty"`
 	Requirements           []Requirement           `json:"requirements,omitempty"`
@@ -14,6 +15,22 @@ type ComplianceFramework struct {
 	ZoneAssignments        []ZoneAssignment        `json:"zoneAssignments,omitempty"`
 	Mappings               []RequirementMapping    `json:"mappings,omitempty"`
 	EnforcementAssessments []EnforcementAssessment `json:"enforcementAssessments,omitempty"`
+	Extensions             Extensions              `json:"extensions,omitempty"`
+	unknown                unknownFields
+}
+
+// UnmarshalJSON decodes a framework bundle, keeping the fields it does not know.
+func (cf *ComplianceFramework) UnmarshalJSON(data []byte) error {
+	type plain ComplianceFramework
+	var err error
+	cf.unknown, err = unmarshalKnown(data, (*plain)(cf), "ComplianceFramework")
+	return err
+}
+
+// MarshalJSON encodes a framework bundle, including the unknown fields it was decoded with.
+func (cf ComplianceFramework) MarshalJSON() ([]byte, error) {
+	type plain ComplianceFramework
+	return marshalKnown(plain(cf), cf.unknown)
 }
 
 // NewComplianceFramework creates a new empty ComplianceFramework.
@@ -98,6 +115,8 @@ func (cf *ComplianceFramework) GetZoneAssignmentsForSolution(solutionID string)
 }
 
 // GetZoneAssignmentsForJurisdiction returns all zone assignments for a given jurisdiction ID.
+// Only exact matches are returned; use EffectiveZoneAssignments to include
+// assignments inherited from parent jurisdictions.
 func (cf *ComplianceFramework) GetZoneAssignmentsForJurisdiction(jurisdictionID string) []ZoneAssignment {
 	var result []ZoneAssignment
 	for _, za := range cf.ZoneAssignments {
@@ -0,0 +1,204 @@
+package comply
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Conflict sources.
+const (
+	ConflictSourceMapping  = "mapping"
+	ConflictSourceResearch = "research"
+)
+
+// ConflictSide is one of the contradictory verdicts on a cell: a mapping,
+// or a research finding identified by its index.
+type ConflictSide struct {
+	Source         string          `json:"source"` // "mapping" or "research"
+	ID             string          `json:"id"`     // mapping ID, or finding index
+	Level          ComplianceLevel `json:"level,omitempty"`
+	Zone           ComplianceZone  `json:"zone,omitempty"`
+	AssessmentDate string          `json:"assessmentDate,omitempty"`
+	Confidence     ConfidenceLevel `json:"confidence,omitempty"`
+	Evidence       []string        `json:"evidence,omitempty"`
+	Notes          string          `json:"notes,omitempty"`
+}
+
+// String renders the side as "mapping MAP-1" or "finding 3".
+func (s ConflictSide) String() string {
+	if s.Source == ConflictSourceResearch {
+		return "finding " + s.ID
+	}
+	return s.Source + " " + s.ID
+}
+
+// MappingConflict is a (solution, requirement, jurisdiction) cell whose
+// verdicts contradict each other: their compliance levels differ, or both
+// set a zone and the zones differ. JurisdictionID is empty for mappings
+// that apply in every jurisdiction.
+type MappingConflict struct {
+	SolutionID     string         `json:"solutionId"`
+	RequirementID  string         `json:"requirementId"`
+	JurisdictionID string         `json:"jurisdictionId,omitempty"`
+	Sides          []ConflictSide `json:"sides"`
+}
+
+// Cell renders the cell as "solution / requirement / jurisdiction".
+func (c MappingConflict) Cell() string {
+	return VerdictChange{SolutionID: c.SolutionID, RequirementID: c.RequirementID, JurisdictionID: c.JurisdictionID}.Cell()
+}
+
+// ConflictReport lists the contradictory cells, sorted by cell.
+type ConflictReport struct {
+	Conflicts []MappingConflict `json:"conflicts,omitempty"`
+}
+
+// Empty reports whether there are no conflicts.
+func (r *ConflictReport) Empty() bool { return len(r.Conflicts) == 0 }
+
+// Conflicts reports the cells on which mappings of the framework disagree.
+// A jurisdiction-specific mapping does not conflict with a mapping without
+// jurisdictions or with one for a parent jurisdiction: it overrides them.
+func (cf *ComplianceFramework) Conflicts() *ConflictReport {
+	cells := newConflictCells()
+	cells.addMappings(cf.Mappings)
+	return cells.report(func([]ConflictSide) bool { return true })
+}
+
+// Conflicts reports the cells on which research findings contradict the
+// mappings of framework or each other. framework may be nil. Findings with
+// status "unknown" are ignored.
+func (ri *ResearchInput) Conflicts(framework *ComplianceFramework) *ConflictReport {
+	cells := newConflictCells()
+	if framework != nil {
+		cells.addMappings(framework.Mappings)
+	}
+	for i, f := range ri.Findings {
+		if f.Status == "unknown" {
+			continue
+		}
+		cells.add(f.SolutionID, f.ControlID, f.JurisdictionIDs, ConflictSide{
+			Source:         ConflictSourceResearch,
+			ID:             strconv.Itoa(i),
+			Level:          f.Level(),
+			Zone:           f.Zone,
+			AssessmentDate: ri.Metadata.ResearchDate,
+			Confidence:     f.Confidence,
+			Evidence:       f.Evidence,
+			Notes:          f.Notes,
+		})
+	}
+	return cells.report(func(sides []ConflictSide) bool {
+		return slices.ContainsFunc(sides, func(s ConflictSide) bool { return s.Source == ConflictSourceResearch })
+	})
+}
+
+// conflictCells collects the verdicts given to each cell.
+type conflictCells struct {
+	sides map[[3]string][]ConflictSide
+}
+
+func newConflictCells() *conflictCells {
+	return &conflictCells{sides: make(map[[3]string][]ConflictSide)}
+}
+
+func (c *conflictCells) addMappings(mappings []RequirementMapping) {
+	for _, m := range mappings {
+		c.add(m.SolutionID, m.RequirementID, m.JurisdictionIDs, ConflictSide{
+			Source:         ConflictSourceMapping,
+			ID:             m.ID,
+			Level:          m.ComplianceLevel,
+			Zone:           m.Zone,
+			AssessmentDate: m.AssessmentDate,
+			Confidence:     m.Confidence,
+			Evidence:       m.Evidence,
+			Notes:          m.Notes,
+		})
+	}
+}
+
+func (c *conflictCells) add(solutionID, requirementID string, jurisdictionIDs []string, side ConflictSide) {
+	jurs := dedupe(jurisdictionIDs)
+	if len(jurs) == 0 {
+		jurs = []string{""}
+	}
+	for _, j := range jurs {
+		key := [3]string{solutionID, requirementID, j}
+		c.sides[key] = append(c.sides[key], side)
+	}
+}
+
+// report returns the contradictory cells whose sides pass include.
+func (c *conflictCells) report(include func([]ConflictSide) bool) *ConflictReport {
+	r := &ConflictReport{}
+	for key, sides := range c.sides {
+		if contradictory(sides) && include(sides) {
+			r.Conflicts = append(r.Conflicts, MappingConflict{SolutionID: key[0], RequirementID: key[1], JurisdictionID: key[2], Sides: sides})
+		}
+	}
+	slices.SortFunc(r.Conflicts, func(a, b MappingConflict) int {
+		return cmp.Or(
+			cmp.Compare(a.SolutionID, b.SolutionID),
+			cmp.Compare(a.RequirementID, b.RequirementID),
+			cmp.Compare(a.JurisdictionID, b.JurisdictionID),
+		)
+	})
+	return r
+}
+
+// contradictory reports whether any two sides disagree on the compliance
+// level, or both set a zone and the zones differ.
+func contradictory(sides []ConflictSide) bool {
+	for i, a := range sides {
+		for _, b := range sides[i+1:] {
+			if a.Level != b.Level || (a.Zone != "" && b.Zone != "" && a.Zone != b.Zone) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Text renders the report as plain text, one block per cell with a line
+// per side.
+func (r *ConflictReport) Text() string {
+	if r.Empty() {
+		return "No conflicts found.\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Conflicts (%d):\n", len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		fmt.Fprintf(&sb, "\n%s\n", c.Cell())
+		for _, s := range c.Sides {
+			fmt.Fprintf(&sb, "  %-22s %-14s %-7s %-10s %s\n", s, orNone(string(s.Level)), orNone(string(s.Zone)),
+				orNone(s.AssessmentDate), "confidence "+orNone(string(s.Confidence)))
+			for _, e := range s.Evidence {
+				fmt.Fprintf(&sb, "      %s\n", e)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Markdown renders the report as Markdown, suitable for pull request
+// comments.
+func (r *ConflictReport) Markdown() string {
+	if r.Empty() {
+		return "No conflicts found.\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Conflicts (%d)\n", len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		fmt.Fprintf(&sb, "\n### %s\n\n", c.Cell())
+		sb.WriteString("| Side | Level | Zone | Assessed | Confidence | Evidence |\n")
+		sb.WriteString("|------|-------|------|----------|------------|----------|\n")
+		for _, s := range c.Sides {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n", s, orNone(string(s.Level)), orNone(string(s.Zone)),
+				orNone(s.AssessmentDate), orNone(string(s.Confidence)), strings.Join(s.Evidence, "<br>"))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,74 @@
+package comply
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrameworkConflicts(t *testing.T) {
+	cf := &ComplianceFramework{Mappings: []RequirementMapping{
+		{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: CompliancePartial, Zone: ZoneYellow, AssessmentDate: "2025-01-01"},
+		{ID: "M2", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceBanned, Confidence: ConfidenceHigh, Evidence: []string{"https://a"}},
+		{ID: "M3", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, ComplianceLevel: CompliancePartial},
+		{ID: "M4", RequirementID: "R1", SolutionID: "aws", ComplianceLevel: ComplianceFull},
+		{ID: "M5", RequirementID: "R2", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceFull, Zone: ZoneGreen},
+		{ID: "M6", RequirementID: "R2", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceFull, Zone: ZoneYellow},
+	}}
+
+	report := cf.Conflicts()
+	var got []string
+	for _, c := range report.Conflicts {
+		var sides []string
+		for _, s := range c.Sides {
+			sides = append(sides, s.String())
+		}
+		got = append(got, c.Cell()+": "+strings.Join(sides, ", "))
+	}
+	want := []string{
+		"aws / R1 / FR: mapping M1, mapping M2",
+		"aws / R2 / FR: mapping M5, mapping M6",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected conflicts:\n%s", strings.Join(got, "\n"))
+	}
+	text := report.Text()
+	for _, s := range []string{"Conflicts (2):", "2025-01-01", "confidence high", "https://a"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("expected text report to contain %q:\n%s", s, text)
+		}
+	}
+	if !strings.Contains(report.Markdown(), "| mapping M2 | banned | (none) | (none) | high | https://a |") {
+		t.Errorf("unexpected markdown:\n%s", report.Markdown())
+	}
+}
+
+func TestResearchConflicts(t *testing.T) {
+	cf := &ComplianceFramework{Mappings: []RequirementMapping{
+		{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceNone},
+		{ID: "M2", RequirementID: "R2", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceFull},
+		{ID: "M3", RequirementID: "R2", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: CompliancePartial},
+	}}
+	ri := &ResearchInput{
+		Metadata: ResearchMetadata{ResearchDate: "2026-10-01"},
+		Findings: []ResearchFinding{
+			{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, Status: "compliant"},
+			{ControlID: "R3", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, Status: "partial"},
+			{ControlID: "R3", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, Status: "banned"},
+			{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, Status: "unknown"},
+		},
+	}
+
+	report := ri.Conflicts(cf)
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", report.Conflicts)
+	}
+	if c := report.Conflicts[0]; c.Cell() != "aws / R1 / FR" || c.Sides[1].String() != "finding 0" || c.Sides[1].AssessmentDate != "2026-10-01" {
+		t.Errorf("unexpected conflict %+v", c)
+	}
+	if c := report.Conflicts[1]; c.Cell() != "aws / R3 / DE" || len(c.Sides) != 2 {
+		t.Errorf("expected the findings to conflict with each other, got %+v", c)
+	}
+	if !(&ComplianceFramework{}).Conflicts().Empty() {
+		t.Error("expected no conflicts for an empty framework")
+	}
+}
@@ -0,0 +1,177 @@
+package comply
+
+import (
+	"slices"
+)
+
+// DefaultSeverityWeights are the weights used for severity-weighted coverage
+// when CoverageOptions.SeverityWeights is not set.
+var DefaultSeverityWeights = map[RequirementSeverity]float64{
+	SeverityCritical: 4,
+	SeverityHigh:     3,
+	SeverityMedium:   2,
+	SeverityLow:      1,
+}
+
+// CoverageOptions filters and weights a coverage calculation. Empty filters match everything.
+type CoverageOptions struct {
+	JurisdictionIDs []string                        `json:"jurisdictionIds,omitempty"`
+	RegulationIDs   []string                        `json:"regulationIds,omitempty"`
+	Categories      []string                        `json:"categories,omitempty"`
+	Severities      []RequirementSeverity           `json:"severities,omitempty"`
+	SeverityWeights map[RequirementSeverity]float64 `json:"severityWeights,omitempty"` // unlisted severities weigh 1
+}
+
+// CoverageStats holds coverage statistics for the framework.
+type CoverageStats struct {
+	TotalRequirements       int                             `json:"totalRequirements"`
+	TotalSolutions          int                             `json:"totalSolutions"`
+	TotalMappings           int                             `json:"totalMappings"`
+	MappingsWithEvidence    int                             `json:"mappingsWithEvidence"`
+	EvidencePercent         float64                         `json:"evidencePercent"`
+	MaxCells                int                             `json:"maxCells"`
+	CoveredCells            int                             `json:"coveredCells"`
+	CoveragePercent         float64                         `json:"coveragePercent"`
+	CellsWithEvidence       int                             `json:"cellsWithEvidence"`
+	CellEvidencePercent     float64                         `json:"cellEvidencePercent"`
+	WeightedCoveragePercent float64                         `json:"weightedCoveragePercent"`
+	JurisdictionIDs         []string                        `json:"jurisdictionIds"` // report order
+	ByJurisdiction          map[string]JurisdictionCoverage `json:"byJurisdiction"`
+}
+
+// JurisdictionCoverage holds coverage stats for a specific jurisdiction.
+type JurisdictionCoverage struct {
+	JurisdictionID          string  `json:"jurisdictionId"`
+	SolutionCount           int     `json:"solutionCount"`
+	MaxCells                int     `json:"maxCells"`
+	CoveredCells            int     `json:"coveredCells"`
+	CoveragePercent         float64 `json:"coveragePercent"`
+	WithEvidence            int     `json:"withEvidence"`
+	EvidencePercent         float64 `json:"evidencePercent"`
+	MissingCells            int     `json:"missingCells"`
+	WeightedCoveragePercent float64 `json:"weightedCoveragePercent"`
+}
+
+func (o *CoverageOptions) matchRequirement(r *Requirement) bool {
+	if len(o.RegulationIDs) > 0 && !slices.Contains(o.RegulationIDs, r.RegulationID) {
+		return false
+	}
+	if len(o.Categories) > 0 && !slices.Contains(o.Categories, r.Category) {
+		return false
+	}
+	if len(o.Severities) > 0 && !slices.Contains(o.Severities, r.Severity) {
+		return false
+	}
+	return true
+}
+
+func (o *CoverageOptions) weight(severity RequirementSeverity) float64 {
+	return severityWeight(o.SeverityWeights, severity)
+}
+
+// Coverage calculates how many requirement/solution cells have a mapping in
+// each jurisdiction. A solution counts toward a jurisdiction when it is
+// available there or in one of its ancestors, and a cell counts as covered
+// when an effective mapping applies (see EffectiveMappings). Jurisdictions
+// are taken from the framework unless opts.JurisdictionIDs is set.
+func (cf *ComplianceFramework) Coverage(opts *CoverageOptions) *CoverageStats {
+	if opts == nil {
+		opts = &CoverageOptions{}
+	}
+
+	requirements := make(map[string]*Requirement)
+	for i := range cf.Requirements {
+		if r := &cf.Requirements[i]; opts.matchRequirement(r) {
+			requirements[r.ID] = r
+		}
+	}
+
+	stats := &CoverageStats{
+		TotalRequirements: len(requirements),
+		TotalSolutions:    len(cf.Solutions),
+		ByJurisdiction:    make(map[string]JurisdictionCoverage),
+	}
+
+	for _, m := range cf.Mappings {
+		if _, ok := requirements[m.RequirementID]; !ok {
+			continue
+		}
+		stats.TotalMappings++
+		if len(m.Evidence) > 0 {
+			stats.MappingsWithEvidence++
+		}
+	}
+	stats.EvidencePercent = percent(stats.MappingsWithEvidence, stats.TotalMappings)
+
+	stats.JurisdictionIDs = opts.JurisdictionIDs
+	if len(stats.JurisdictionIDs) == 0 {
+		stats.JurisdictionIDs = mapIDs(cf.Jurisdictions, func(j Jurisdiction) string { return j.ID })
+	}
+
+	var totalWeight float64
+	for i := range cf.Requirements {
+		if r := &cf.Requirements[i]; requirements[r.ID] != nil {
+			totalWeight += opts.weight(r.Severity)
+		}
+	}
+
+	h := cf.Hierarchy()
+	var allWeight, allCoveredWeight float64
+	for _, jurID := range stats.JurisdictionIDs {
+		solutions := make(map[string]bool)
+		for _, s := range cf.Solutions {
+			if _, d := h.nearest(jurID, s.JurisdictionIDs); d >= 0 {
+				solutions[s.ID] = true
+			}
+		}
+
+		jc := JurisdictionCoverage{
+			JurisdictionID: jurID,
+			SolutionCount:  len(solutions),
+			MaxCells:       len(requirements) * len(solutions),
+		}
+
+		var coveredWeight float64
+		for _, m := range cf.EffectiveMappings(jurID) {
+			req, ok := requirements[m.RequirementID]
+			if !ok || !solutions[m.SolutionID] {
+				continue
+			}
+			jc.CoveredCells++
+			coveredWeight += opts.weight(req.Severity)
+			if len(m.Evidence) > 0 {
+				jc.WithEvidence++
+			}
+		}
+
+		jc.MissingCells = jc.MaxCells - jc.CoveredCells
+		jc.CoveragePercent = percent(jc.CoveredCells, jc.MaxCells)
+		jc.EvidencePercent = percent(jc.WithEvidence, jc.CoveredCells)
+		jurWeight := totalWeight * float64(len(solutions))
+		if jurWeight > 0 {
+			jc.WeightedCoveragePercent = coveredWeight / jurWeight * 100
+		}
+
+		stats.ByJurisdiction[jurID] = jc
+		stats.MaxCells += jc.MaxCells
+		stats.CoveredCells += jc.CoveredCells
+		stats.CellsWithEvidence += jc.WithEvidence
+		allWeight += jurWeight
+		allCoveredWeight += coveredWeight
+	}
+
+	stats.CoveragePercent = percent(stats.CoveredCells, stats.MaxCells)
+	stats.CellEvidencePercent = percent(stats.CellsWithEvidence, stats.CoveredCells)
+	if allWeight > 0 {
+		stats.WeightedCoveragePercent = allCoveredWeight / allWeight * 100
+	}
+
+	return stats
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
@@ -0,0 +1,95 @@
+package comply
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func coverageTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+			{ID: "BR", Type: JurisdictionCountry},
+		},
+		Requirements: []Requirement{
+			{ID: "REQ-001", RegulationID: "EU-GDPR", Category: "data-residency", Severity: SeverityCritical},
+			{ID: "REQ-002", RegulationID: "EU-GDPR", Category: "encryption", Severity: SeverityLow},
+		},
+		Solutions: []Solution{
+			{ID: "aws", JurisdictionIDs: []string{"EU", "BR"}},
+			{ID: "ovh", JurisdictionIDs: []string{"FR"}},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"EU"}, Evidence: []string{"https://example.com"}},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "ovh", JurisdictionIDs: []string{"FR"}},
+			{ID: "M3", RequirementID: "REQ-002", SolutionID: "aws", JurisdictionIDs: []string{"BR"}},
+		},
+	}
+}
+
+func TestCoverageUsesFrameworkJurisdictions(t *testing.T) {
+	stats := coverageTestFramework().Coverage(nil)
+
+	if !slices.Equal(stats.JurisdictionIDs, []string{"EU", "FR", "BR"}) {
+		t.Fatalf("expected jurisdictions from framework, got %v", stats.JurisdictionIDs)
+	}
+
+	fr := stats.ByJurisdiction["FR"]
+	if fr.SolutionCount != 2 {
+		t.Errorf("expected 2 solutions in FR (ovh + inherited aws), got %d", fr.SolutionCount)
+	}
+	if fr.MaxCells != 4 || fr.CoveredCells != 2 || fr.MissingCells != 2 {
+		t.Errorf("expected FR 2/4 cells covered, got %+v", fr)
+	}
+	if fr.WithEvidence != 1 {
+		t.Errorf("expected 1 FR cell with evidence, got %d", fr.WithEvidence)
+	}
+
+	br := stats.ByJurisdiction["BR"]
+	if br.MaxCells != 2 || br.CoveredCells != 1 {
+		t.Errorf("expected BR 1/2 cells covered, got %+v", br)
+	}
+}
+
+func TestCoverageFilters(t *testing.T) {
+	cf := coverageTestFramework()
+
+	stats := cf.Coverage(&CoverageOptions{
+		JurisdictionIDs: []string{"FR"},
+		Severities:      []RequirementSeverity{SeverityCritical},
+	})
+	if len(stats.ByJurisdiction) != 1 {
+		t.Fatalf("expected only FR, got %v", stats.JurisdictionIDs)
+	}
+	if stats.TotalRequirements != 1 || stats.TotalMappings != 2 {
+		t.Errorf("expected 1 requirement and 2 mappings, got %d and %d", stats.TotalRequirements, stats.TotalMappings)
+	}
+	if fr := stats.ByJurisdiction["FR"]; fr.CoveragePercent != 100 {
+		t.Errorf("expected 100%% FR coverage for critical requirements, got %.1f", fr.CoveragePercent)
+	}
+
+	stats = cf.Coverage(&CoverageOptions{Categories: []string{"encryption"}})
+	if stats.TotalRequirements != 1 || stats.ByJurisdiction["EU"].CoveredCells != 0 {
+		t.Errorf("expected no EU coverage for encryption, got %+v", stats.ByJurisdiction["EU"])
+	}
+}
+
+func TestCoverageSeverityWeighting(t *testing.T) {
+	cf := coverageTestFramework()
+
+	// EU: aws is the only solution; REQ-001 (critical, weight 4) covered, REQ-002 (low, weight 1) not.
+	eu := cf.Coverage(nil).ByJurisdiction["EU"]
+	if eu.CoveragePercent != 50 {
+		t.Errorf("expected 50%% unweighted EU coverage, got %.1f", eu.CoveragePercent)
+	}
+	if math.Abs(eu.WeightedCoveragePercent-80) > 0.001 {
+		t.Errorf("expected 80%% weighted EU coverage, got %.1f", eu.WeightedCoveragePercent)
+	}
+
+	eu = cf.Coverage(&CoverageOptions{SeverityWeights: map[RequirementSeverity]float64{SeverityCritical: 1, SeverityLow: 1}}).ByJurisdiction["EU"]
+	if eu.WeightedCoveragePercent != 50 {
+		t.Errorf("expected 50%% with equal weights, got %.1f", eu.WeightedCoveragePercent)
+	}
+}
@@ -0,0 +1,374 @@
+package comply
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChangeType classifies a change between two framework versions.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// FieldChange is a change to a single JSON field. Old and New hold the
+// decoded JSON values and are nil when the field is absent.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old,omitempty"`
+	New   any    `json:"new,omitempty"`
+}
+
+// EntityChange is an added, removed or modified entity.
+type EntityChange struct {
+	ID     string        `json:"id"`
+	Type   ChangeType    `json:"type"`
+	Fields []FieldChange `json:"fields,omitempty"` // modified entities only
+}
+
+// CollectionDiff holds the changes to one framework collection.
+type CollectionDiff struct {
+	Collection string         `json:"collection"` // JSON name, e.g. "mappings"
+	Kind       EntityKind     `json:"kind"`
+	Added      int            `json:"added"`
+	Removed    int            `json:"removed"`
+	Modified   int            `json:"modified"`
+	Changes    []EntityChange `json:"changes"`
+}
+
+// VerdictChange is a change to the compliance level or zone of a
+// (solution, requirement, jurisdiction) cell. JurisdictionID is empty for
+// mappings that apply in every jurisdiction.
+type VerdictChange struct {
+	SolutionID     string          `json:"solutionId"`
+	RequirementID  string          `json:"requirementId"`
+	JurisdictionID string          `json:"jurisdictionId,omitempty"`
+	Type           ChangeType      `json:"type"`
+	OldMappingID   string          `json:"oldMappingId,omitempty"`
+	NewMappingID   string          `json:"newMappingId,omitempty"`
+	OldLevel       ComplianceLevel `json:"oldLevel,omitempty"`
+	NewLevel       ComplianceLevel `json:"newLevel,omitempty"`
+	OldZone        ComplianceZone  `json:"oldZone,omitempty"`
+	NewZone        ComplianceZone  `json:"newZone,omitempty"`
+}
+
+// LevelChanged reports whether the compliance level changed.
+func (v VerdictChange) LevelChanged() bool { return v.OldLevel != v.NewLevel }
+
+// ZoneChanged reports whether the zone changed.
+func (v VerdictChange) ZoneChanged() bool { return v.OldZone != v.NewZone }
+
+// String renders the change, e.g. "aws-commercial / CTL-LEGAL-001 / FR: partial → banned".
+func (v VerdictChange) String() string {
+	var parts []string
+	if v.LevelChanged() {
+		parts = append(parts, fmt.Sprintf("%s → %s", orNone(string(v.OldLevel)), orNone(string(v.NewLevel))))
+	}
+	if v.ZoneChanged() {
+		parts = append(parts, fmt.Sprintf("zone %s → %s", orNone(string(v.OldZone)), orNone(string(v.NewZone))))
+	}
+	return fmt.Sprintf("%s: %s", v.Cell(), strings.Join(parts, ", "))
+}
+
+// Cell renders the changed cell as "solution / requirement / jurisdiction".
+func (v VerdictChange) Cell() string {
+	jur := v.JurisdictionID
+	if jur == "" {
+		jur = "all"
+	}
+	return fmt.Sprintf("%s / %s / %s", v.SolutionID, v.RequirementID, jur)
+}
+
+// FrameworkDiff is the semantic difference between two framework versions.
+type FrameworkDiff struct {
+	OldVersion  string           `json:"oldVersion,omitempty"`
+	NewVersion  string           `json:"newVersion,omitempty"`
+	Metadata    []FieldChange    `json:"metadata,omitempty"`
+	Verdicts    []VerdictChange  `json:"verdicts,omitempty"`
+	Collections []CollectionDiff `json:"collections,omitempty"` // collections with changes only
+}
+
+// Empty reports whether the two frameworks are equivalent.
+func (d *FrameworkDiff) Empty() bool {
+	return len(d.Metadata) == 0 && len(d.Verdicts) == 0 && len(d.Collections) == 0
+}
+
+// Collection returns the diff for a collection by JSON name, or nil if it has no changes.
+func (d *FrameworkDiff) Collection(name string) *CollectionDiff {
+	for i := range d.Collections {
+		if d.Collections[i].Collection == name {
+			return &d.Collections[i]
+		}
+	}
+	return nil
+}
+
+// Diff compares two frameworks. Entities are matched by ID and compared
+// field by field using their JSON representation. Mapping verdicts are
+// compared per (solution, requirement, jurisdiction) cell, so a verdict
+// change is reported even when a mapping was split or renamed.
+func Diff(old, new *ComplianceFramework) *FrameworkDiff {
+	d := &FrameworkDiff{OldVersion: old.Version, NewVersion: new.Version}
+
+	for _, f := range []struct {
+		name     string
+		old, new string
+	}{
+		{"name", old.Name, new.Name},
+		{"version", old.Version, new.Version},
+		{"description", old.Description, new.Description},
+		{"lastUpdated", old.LastUpdated, new.LastUpdated},
+	} {
+		if f.old != f.new {
+			d.Metadata = append(d.Metadata, FieldChange{Field: f.name, Old: emptyToNil(f.old), New: emptyToNil(f.new)})
+		}
+	}
+
+	for _, cd := range []CollectionDiff{
+		diffCollection(KindJurisdiction, "jurisdictions", old.Jurisdictions, new.Jurisdictions, func(j *Jurisdiction) string { return j.ID }),
+		diffCollection(KindRegulation, "regulations", old.Regulations, new.Regulations, func(r *Regulation) string { return r.ID }),
+		diffCollection(KindRequirement, "requirements", old.Requirements, new.Requirements, func(r *Requirement) string { return r.ID }),
+		diffCollection(KindRegulatedEntity, "regulatedEntities", old.RegulatedEntities, new.RegulatedEntities, func(e *RegulatedEntity) string { return e.ID }),
+		diffCollection(KindSolution, "solutions", old.Solutions, new.Solutions, func(s *Solution) string { return s.ID }),
+		diffCollection(KindZoneAssignment, "zoneAssignments", old.ZoneAssignments, new.ZoneAssignments, func(z *ZoneAssignment) string { return z.ID }),
+		diffCollection(KindMapping, "mappings", old.Mappings, new.Mappings, func(m *RequirementMapping) string { return m.ID }),
+		diffCollection(KindEnforcementAssessment, "enforcementAssessments", old.EnforcementAssessments, new.EnforcementAssessments, func(e *EnforcementAssessment) string { return e.ID }),
+	} {
+		if len(cd.Changes) > 0 {
+			d.Collections = append(d.Collections, cd)
+		}
+	}
+
+	d.Verdicts = diffVerdicts(old.Mappings, new.Mappings)
+	return d
+}
+
+func diffCollection[T any](kind EntityKind, name string, old, new []T, id func(*T) string) CollectionDiff {
+	cd := CollectionDiff{Collection: name, Kind: kind, Changes: []EntityChange{}}
+	oldByID := indexByID(old, id)
+	newByID := indexByID(new, id)
+
+	for i := range old {
+		oid := id(&old[i])
+		if oldByID[oid] != i {
+			continue // duplicate ID; the first occurrence is compared
+		}
+		j, ok := newByID[oid]
+		if !ok {
+			cd.Removed++
+			cd.Changes = append(cd.Changes, EntityChange{ID: oid, Type: ChangeRemoved})
+			continue
+		}
+		if fields := fieldChanges(old[i], new[j]); len(fields) > 0 {
+			cd.Modified++
+			cd.Changes = append(cd.Changes, EntityChange{ID: oid, Type: ChangeModified, Fields: fields})
+		}
+	}
+	for j := range new {
+		nid := id(&new[j])
+		if _, ok := oldByID[nid]; !ok && newByID[nid] == j {
+			cd.Added++
+			cd.Changes = append(cd.Changes, EntityChange{ID: nid, Type: ChangeAdded})
+		}
+	}
+	return cd
+}
+
+// fieldChanges compares two values by their top-level JSON fields.
+func fieldChanges(a, b any) []FieldChange {
+	am, bm := jsonFields(a), jsonFields(b)
+	keys := make([]string, 0, len(am)+len(bm))
+	for k := range am {
+		keys = append(keys, k)
+	}
+	for k := range bm {
+		if _, ok := am[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []FieldChange
+	for _, k := range keys {
+		if !reflect.DeepEqual(am[k], bm[k]) {
+			changes = append(changes, FieldChange{Field: k, Old: am[k], New: bm[k]})
+		}
+	}
+	return changes
+}
+
+func jsonFields(v any) map[string]any {
+	fields := make(map[string]any)
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+func diffVerdicts(old, new []RequirementMapping) []VerdictChange {
+	oldCells, newCells := mappingStates(old), mappingStates(new)
+	var changes []VerdictChange
+
+	for key, o := range oldCells {
+		v := VerdictChange{SolutionID: key[0], RequirementID: key[1], JurisdictionID: key[2],
+			OldMappingID: o.MappingID, OldLevel: o.ComplianceLevel, OldZone: o.Zone}
+		if n, ok := newCells[key]; ok {
+			v.NewMappingID, v.NewLevel, v.NewZone = n.MappingID, n.ComplianceLevel, n.Zone
+			if !v.LevelChanged() && !v.ZoneChanged() {
+				continue
+			}
+			v.Type = ChangeModified
+		} else {
+			v.Type = ChangeRemoved
+		}
+		changes = append(changes, v)
+	}
+	for key, n := range newCells {
+		if _, ok := oldCells[key]; !ok {
+			changes = append(changes, VerdictChange{SolutionID: key[0], RequirementID: key[1], JurisdictionID: key[2],
+				Type: ChangeAdded, NewMappingID: n.MappingID, NewLevel: n.ComplianceLevel, NewZone: n.Zone})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b VerdictChange) int {
+		return cmp.Or(
+			cmp.Compare(a.SolutionID, b.SolutionID),
+			cmp.Compare(a.RequirementID, b.RequirementID),
+			cmp.Compare(a.JurisdictionID, b.JurisdictionID),
+		)
+	})
+	return changes
+}
+
+// Text renders the diff as plain text.
+func (d *FrameworkDiff) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Framework diff: %s → %s\n", orNone(d.OldVersion), orNone(d.NewVersion))
+	if d.Empty() {
+		sb.WriteString("\nNo changes.\n")
+		return sb.String()
+	}
+
+	if len(d.Metadata) > 0 {
+		sb.WriteString("\nMetadata:\n")
+		for _, f := range d.Metadata {
+			fmt.Fprintf(&sb, "  %s: %s → %s\n", f.Field, formatValue(f.Old), formatValue(f.New))
+		}
+	}
+
+	if len(d.Verdicts) > 0 {
+		fmt.Fprintf(&sb, "\nVerdict changes (%d):\n", len(d.Verdicts))
+		for _, v := range d.Verdicts {
+			fmt.Fprintf(&sb, "  %s\n", v)
+		}
+	}
+
+	for _, cd := range d.Collections {
+		fmt.Fprintf(&sb, "\n%s: %d added, %d removed, %d modified\n", cd.Collection, cd.Added, cd.Removed, cd.Modified)
+		for _, c := range cd.Changes {
+			fmt.Fprintf(&sb, "  %s %s\n", changeSymbol(c.Type), c.ID)
+			for _, f := range c.Fields {
+				fmt.Fprintf(&sb, "      %s: %s → %s\n", f.Field, formatValue(f.Old), formatValue(f.New))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Markdown renders the diff as Markdown, suitable for pull request comments.
+func (d *FrameworkDiff) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Framework diff: %s → %s\n", orNone(d.OldVersion), orNone(d.NewVersion))
+	if d.Empty() {
+		sb.WriteString("\nNo changes.\n")
+		return sb.String()
+	}
+
+	if len(d.Metadata) > 0 {
+		sb.WriteString("\n### Metadata\n\n")
+		for _, f := range d.Metadata {
+			fmt.Fprintf(&sb, "- `%s`: %s → %s\n", f.Field, markdownCell(formatValue(f.Old)), markdownCell(formatValue(f.New)))
+		}
+	}
+
+	if len(d.Verdicts) > 0 {
+		fmt.Fprintf(&sb, "\n### Verdict changes (%d)\n\n", len(d.Verdicts))
+		sb.WriteString("| Solution | Requirement | Jurisdiction | Level | Zone |\n|----------|-------------|--------------|-------|------|\n")
+		for _, v := range d.Verdicts {
+			level, zone := "", ""
+			if v.LevelChanged() {
+				level = fmt.Sprintf("%s → **%s**", orNone(string(v.OldLevel)), orNone(string(v.NewLevel)))
+			}
+			if v.ZoneChanged() {
+				zone = fmt.Sprintf("%s → **%s**", orNone(string(v.OldZone)), orNone(string(v.NewZone)))
+			}
+			jur := v.JurisdictionID
+			if jur == "" {
+				jur = "all"
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", v.SolutionID, v.RequirementID, jur, level, zone)
+		}
+	}
+
+	for _, cd := range d.Collections {
+		fmt.Fprintf(&sb, "\n### %s (%d added, %d removed, %d modified)\n\n", cd.Collection, cd.Added, cd.Removed, cd.Modified)
+		for _, c := range cd.Changes {
+			fmt.Fprintf(&sb, "- %s `%s`\n", c.Type, c.ID)
+			for _, f := range c.Fields {
+				fmt.Fprintf(&sb, "  - `%s`: %s → %s\n", f.Field, markdownCell(formatValue(f.Old)), markdownCell(formatValue(f.New)))
+			}
+		}
+	}
+	return sb.String()
+}
+
+func changeSymbol(t ChangeType) string {
+	switch t {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// formatValue renders a decoded JSON value for display, truncating long values.
+func formatValue(v any) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		s = v
+	default:
+		data, _ := json.Marshal(v)
+		s = string(data)
+	}
+	const maxLen = 100
+	if r := []rune(s); len(r) > maxLen {
+		s = string(r[:maxLen-1]) + "…"
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func emptyToNil(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,87 @@
+package comply
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffTestFrameworks() (*ComplianceFramework, *ComplianceFramework) {
+	old := &ComplianceFramework{
+		Version: "1.0.0",
+		Requirements: []Requirement{
+			{ID: "REQ-001", Name: "Residency", Severity: SeverityHigh},
+			{ID: "REQ-002", Name: "Removed"},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: CompliancePartial, Zone: ZoneYellow},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "ovh", ComplianceLevel: ComplianceFull},
+		},
+	}
+	updated := &ComplianceFramework{
+		Version: "1.1.0",
+		Requirements: []Requirement{
+			{ID: "REQ-001", Name: "Residency", Severity: SeverityCritical},
+			{ID: "REQ-003", Name: "Added"},
+		},
+		Mappings: []RequirementMapping{
+			// M1 is split per jurisdiction; only FR changes verdict.
+			{ID: "M1-FR", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceBanned, Zone: ZoneRed},
+			{ID: "M1-DE", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, ComplianceLevel: CompliancePartial, Zone: ZoneYellow},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "ovh", ComplianceLevel: ComplianceFull, Notes: "Reviewed"},
+		},
+	}
+	return old, updated
+}
+
+func TestDiff(t *testing.T) {
+	d := Diff(diffTestFrameworks())
+
+	if len(d.Metadata) != 1 || d.Metadata[0].Field != "version" {
+		t.Errorf("expected a version change, got %+v", d.Metadata)
+	}
+
+	reqs := d.Collection("requirements")
+	if reqs == nil || reqs.Added != 1 || reqs.Removed != 1 || reqs.Modified != 1 {
+		t.Fatalf("expected 1 added, 1 removed, 1 modified requirement, got %+v", reqs)
+	}
+	for _, c := range reqs.Changes {
+		if c.Type == ChangeModified {
+			if len(c.Fields) != 1 || c.Fields[0].Field != "severity" || c.Fields[0].Old != "high" || c.Fields[0].New != "critical" {
+				t.Errorf("expected severity high → critical, got %+v", c.Fields)
+			}
+		}
+	}
+
+	maps := d.Collection("mappings")
+	if maps == nil || maps.Added != 2 || maps.Removed != 1 || maps.Modified != 1 {
+		t.Errorf("expected 2 added, 1 removed, 1 modified mapping, got %+v", maps)
+	}
+
+	if len(d.Verdicts) != 1 {
+		t.Fatalf("expected 1 verdict change, got %v", d.Verdicts)
+	}
+	if got := d.Verdicts[0].String(); got != "aws / REQ-001 / FR: partial → banned, zone yellow → red" {
+		t.Errorf("unexpected verdict change %q", got)
+	}
+}
+
+func TestDiffRendering(t *testing.T) {
+	d := Diff(diffTestFrameworks())
+
+	text := d.Text()
+	for _, want := range []string{"Framework diff: 1.0.0 → 1.1.0", "aws / REQ-001 / FR: partial → banned", "  + REQ-003", "      severity: high → critical"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text output to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	md := d.Markdown()
+	if !strings.Contains(md, "| aws | REQ-001 | FR | partial → **banned** | yellow → **red** |") {
+		t.Errorf("expected verdict table row in markdown, got:\n%s", md)
+	}
+
+	old, _ := diffTestFrameworks()
+	if same := Diff(old, old); !same.Empty() || !strings.Contains(same.Text(), "No changes.") {
+		t.Errorf("expected no changes when diffing a framework with itself, got %+v", same)
+	}
+}
@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // EnforcementLikelihood defines the likelihood of enforcement action.
 type EnforcementLikelihood string
 
@@ -10,6 +12,17 @@ const (
 	LikelihoodUncertain EnforcementLikelihood = "uncertain"
 )
 
+// EnforcementLikelihoods lists the valid EnforcementLikelihood values.
+var EnforcementLikelihoods = registerEnum(LikelihoodHigh, LikelihoodMedium, LikelihoodLow, LikelihoodUncertain)
+
+// Valid reports whether l is a known enforcement likelihood.
+func (l EnforcementLikelihood) Valid() bool { return slices.Contains(EnforcementLikelihoods, l) }
+
+// UnmarshalJSON decodes a enforcement likelihood, rejecting unknown values with an *EnumError.
+func (l *EnforcementLikelihood) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, l, EnforcementLikelihoods)
+}
+
 // EnforcementAssessment evaluates the likelihood and nature of enforcement.
 type EnforcementAssessment struct {
 	ID               string                `json:"id"`
@@ -20,8 +33,24 @@ type EnforcementAssessment struct {
 	Rationale        string                `json:"rationale"`
 	RecentActions    []EnforcementAction   `json:"recentActions,omitempty"`
 	RegulatoryTrends string                `json:"regulatoryTrends,omitempty"`
-	AssessmentDate   string                `json:"assessmentDate"`
+	AssessmentDate   string                `json:"assessmentDate" jsonschema:"format=date"`
 	Assessor         string                `json:"assessor,omitempty"`
+	Extensions       Extensions            `json:"extensions,omitempty"`
+	unknown          unknownFields
+}
+
+// UnmarshalJSON decodes an enforcement assessment, keeping the fields it does not know.
+func (a *EnforcementAssessment) UnmarshalJSON(data []byte) error {
+	type plain EnforcementAssessment
+	var err error
+	a.unknown, err = unmarshalKnown(data, (*plain)(a), "EnforcementAssessment")
+	return err
+}
+
+// MarshalJSON encodes an enforcement assessment, including the unknown fields it was decoded with.
+func (a EnforcementAssessment) MarshalJSON() ([]byte, error) {
+	type plain EnforcementAssessment
+	return marshalKnown(plain(a), a.unknown)
 }
 
 // EnforcementAction represents a specific enforcement action that has occurred.
@@ -31,4 +60,19 @@ type EnforcementAction struct {
 	Description string `json:"description"`
 	Penalty     string `json:"penalty,omitempty"`
 	Source      string `json:"source,omitempty"`
+	unknown     unknownFields
+}
+
+// UnmarshalJSON decodes an enforcement action, keeping the fields it does not know.
+func (a *EnforcementAction) UnmarshalJSON(data []byte) error {
+	type plain EnforcementAction
+	var err error
+	a.unknown, err = unmarshalKnown(data, (*plain)(a), "EnforcementAction")
+	return err
+}
+
+// MarshalJSON encodes an enforcement action, including the unknown fields it was decoded with.
+func (a EnforcementAction) MarshalJSON() ([]byte, error) {
+	type plain EnforcementAction
+	return marshalKnown(plain(a), a.unknown)
 }
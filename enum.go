@@ -0,0 +1,208 @@
+package comply
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EnumError reports a value that is not one of an enum type's allowed values.
+type EnumError struct {
+	Type    string   `json:"type"` // Go type name, e.g., "ComplianceLevel"
+	Value   string   `json:"value"`
+	Allowed []string `json:"allowed"`
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("invalid %s %q (allowed: %s)", e.Type, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+// enumValues maps each enum type to its allowed values. Types are added by
+// registerEnum when their value lists are initialized.
+var enumValues = map[reflect.Type][]string{}
+
+// registerEnum records the allowed values of an enum type and returns them.
+func registerEnum[T ~string](values ...T) []T {
+	allowed := make([]string, len(values))
+	for i, v := range values {
+		allowed[i] = string(v)
+	}
+	enumValues[reflect.TypeFor[T]()] = allowed
+	return values
+}
+
+// unmarshalEnum decodes a JSON string into an enum, rejecting values that are
+// not allowed. The empty string is accepted as "not set".
+func unmarshalEnum[T ~string](data []byte, v *T, allowed []T) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s != "" && !slices.Contains(allowed, T(s)) {
+		return &EnumError{Type: reflect.TypeFor[T]().Name(), Value: s, Allowed: enumValues[reflect.TypeFor[T]()]}
+	}
+	*v = T(s)
+	return nil
+}
+
+// DecodeMode controls how loaders handle invalid enum values.
+type DecodeMode string
+
+const (
+	// DecodeDefault fails on the first invalid value, without its location.
+	DecodeDefault DecodeMode = ""
+	// DecodeStrict fails with a DecodeError listing every invalid value with
+	// its file and JSON path.
+	DecodeStrict DecodeMode = "strict"
+	// DecodeLenient drops invalid values and records them as warnings.
+	DecodeLenient DecodeMode = "lenient"
+)
+
+// DecodeIssue is an invalid enum value found while decoding a file.
+type DecodeIssue struct {
+	File string `json:"file"`
+	Path string `json:"path"` // JSON path, e.g., "$[3].complianceLevel"
+	EnumError
+}
+
+// String renders the issue as "file: path: message".
+func (i DecodeIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Path, i.EnumError.Error())
+}
+
+// DecodeError is returned by strict loaders and lists every invalid value.
+type DecodeError struct {
+	Issues []DecodeIssue `json:"issues"`
+}
+
+func (e *DecodeError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkEnums walks decoded JSON alongside the Go type it will be decoded into
+// and reports invalid enum values. When drop is true, invalid values are
+// removed from v so that decoding succeeds.
+func checkEnums(file string, t reflect.Type, v any, drop bool) (any, []DecodeIssue) {
+	var issues []DecodeIssue
+	v = walkEnums(t, v, func(path string, t reflect.Type, allowed []string, s string) (any, bool) {
+		if slices.Contains(allowed, s) {
+			return s, true
+		}
+		issues = append(issues, DecodeIssue{File: file, Path: path,
+			EnumError: EnumError{Type: t.Name(), Value: s, Allowed: allowed}})
+		return s, !drop
+	})
+	slices.SortFunc(issues, func(a, b DecodeIssue) int { return strings.Compare(a.Path, b.Path) })
+	return v, issues
+}
+
+// walkEnums walks decoded JSON alongside the Go type it will be decoded into
+// and calls visit with the JSON path of every non-empty enum string. visit
+// returns the value to keep, or false to remove it.
+func walkEnums(t reflect.Type, v any, visit func(path string, t reflect.Type, allowed []string, s string) (any, bool)) any {
+	var walk func(t reflect.Type, v any, path string) (any, bool)
+	walk = func(t reflect.Type, v any, path string) (any, bool) {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if allowed, ok := enumValues[t]; ok {
+			s, isString := v.(string)
+			if !isString || s == "" {
+				return v, true
+			}
+			return visit(path, t, allowed, s)
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return v, true
+			}
+			walkFields(t, obj, path, walk)
+		case reflect.Slice, reflect.Array:
+			arr, ok := v.([]any)
+			if !ok {
+				return v, true
+			}
+			kept := arr[:0]
+			for i, elem := range arr {
+				if elem, keep := walk(t.Elem(), elem, fmt.Sprintf("%s[%d]", path, i)); keep {
+					kept = append(kept, elem)
+				}
+			}
+			return kept, true
+		case reflect.Map:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return v, true
+			}
+			for key, elem := range obj {
+				if elem, keep := walk(t.Elem(), elem, jsonPath(path, key)); keep {
+					obj[key] = elem
+				} else {
+					delete(obj, key)
+				}
+			}
+		}
+		return v, true
+	}
+
+	v, _ = walk(t, v, "$")
+	return v
+}
+
+// walkFields walks the JSON fields of a struct, including promoted fields of
+// embedded structs, and deletes the fields that walk does not keep.
+func walkFields(t reflect.Type, obj map[string]any, path string, walk func(reflect.Type, any, string) (any, bool)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if _, isEnum := enumValues[ft]; !isEnum {
+					walkFields(ft, obj, path, walk)
+					continue
+				}
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		elem, ok := obj[name]
+		if !ok {
+			continue
+		}
+		if elem, keep := walk(f.Type, elem, jsonPath(path, name)); keep {
+			obj[name] = elem
+		} else {
+			delete(obj, name)
+		}
+	}
+}
+
+// jsonPath appends a member to a JSON path, quoting keys that are not identifiers.
+func jsonPath(path, key string) string {
+	for i, r := range key {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && (r >= '0' && r <= '9' || r == '-')) {
+			return fmt.Sprintf("%s[%q]", path, key)
+		}
+	}
+	if key == "" {
+		return path + `[""]`
+	}
+	return path + "." + key
+}
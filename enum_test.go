@@ -0,0 +1,92 @@
+package comply
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnumUnmarshal(t *testing.T) {
+	var m RequirementMapping
+	if err := json.Unmarshal([]byte(`{"id":"M1","complianceLevel":"partial","zone":""}`), &m); err != nil {
+		t.Fatalf("expected valid mapping to decode, got %v", err)
+	}
+	if m.ComplianceLevel != CompliancePartial || !m.ComplianceLevel.Valid() {
+		t.Errorf("expected partial, got %q", m.ComplianceLevel)
+	}
+
+	err := json.Unmarshal([]byte(`{"id":"M1","complianceLevel":"complaint"}`), &m)
+	var enumErr *EnumError
+	if !errors.As(err, &enumErr) || enumErr.Type != "ComplianceLevel" || enumErr.Value != "complaint" || len(enumErr.Allowed) != len(ComplianceLevels) {
+		t.Errorf("expected EnumError for complaint, got %v", err)
+	}
+}
+
+func writeEnumTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"mappings.json": `[
+			{"id": "M1", "requirementId": "R1", "solutionId": "S1", "complianceLevel": "compliant"},
+			{"id": "M2", "requirementId": "R1", "solutionId": "S2", "complianceLevel": "complaint", "confidence": "hgh"}
+		]`,
+		"entities.json": `[{"id": "E1", "sizes": ["large", "huge", "medium"]}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoaderStrict(t *testing.T) {
+	dir := writeEnumTestDir(t)
+	_, err := (&Loader{Mode: DecodeStrict}).LoadFrameworkFromDir(dir)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected DecodeError, got %v", err)
+	}
+	var paths []string
+	for _, issue := range decodeErr.Issues {
+		paths = append(paths, filepath.Base(issue.File)+" "+issue.Path)
+	}
+	expected := []string{
+		"entities.json $[0].sizes[1]",
+		"mappings.json $[1].complianceLevel",
+		"mappings.json $[1].confidence",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected issues %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("expected issue %d at %s, got %s", i, expected[i], paths[i])
+		}
+	}
+}
+
+func TestLoaderLenient(t *testing.T) {
+	dir := writeEnumTestDir(t)
+	loader := &Loader{Mode: DecodeLenient}
+	cf, err := loader.LoadFrameworkFromDir(dir)
+	if err != nil {
+		t.Fatalf("expected lenient load to succeed, got %v", err)
+	}
+	if len(loader.Warnings) != 3 {
+		t.Errorf("expected 3 warnings, got %v", loader.Warnings)
+	}
+	if len(cf.Mappings) != 2 || cf.Mappings[1].ComplianceLevel != "" || cf.Mappings[1].Confidence != "" {
+		t.Errorf("expected invalid values to be dropped, got %+v", cf.Mappings)
+	}
+	if sizes := cf.RegulatedEntities[0].Sizes; len(sizes) != 2 || sizes[1] != SizeMedium {
+		t.Errorf("expected huge to be dropped from sizes, got %v", sizes)
+	}
+
+	if _, err := LoadFrameworkFromDir(dir); err == nil {
+		t.Error("expected default load to fail on invalid values")
+	}
+}
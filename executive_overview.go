@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // ExecutiveOverview contains market segment analysis and provider readiness data
 type ExecutiveOverview struct {
 	Metadata          ExecutiveOverviewMetadata  `json:"metadata"`
@@ -29,6 +31,17 @@ const (
 	SegmentGovernment  SegmentType = "government"
 )
 
+// SegmentTypes lists the valid SegmentType values.
+var SegmentTypes = registerEnum(SegmentCommercial, SegmentRegulated, SegmentGovernment)
+
+// Valid reports whether t is a known segment type.
+func (t SegmentType) Valid() bool { return slices.Contains(SegmentTypes, t) }
+
+// UnmarshalJSON decodes a segment type, rejecting unknown values with an *EnumError.
+func (t *SegmentType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, t, SegmentTypes)
+}
+
 // RequirementPriority represents the priority of a requirement
 type RequirementPriority string
 
@@ -38,6 +51,17 @@ const (
 	PriorityNiceToHave RequirementPriority = "nice-to-have"
 )
 
+// RequirementPriorities lists the valid RequirementPriority values.
+var RequirementPriorities = registerEnum(PriorityMustHave, PriorityShouldHave, PriorityNiceToHave)
+
+// Valid reports whether p is a known requirement priority.
+func (p RequirementPriority) Valid() bool { return slices.Contains(RequirementPriorities, p) }
+
+// UnmarshalJSON decodes a requirement priority, rejecting unknown values with an *EnumError.
+func (p *RequirementPriority) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, p, RequirementPriorities)
+}
+
 // RequirementEnforcementStatus represents the enforcement status of a requirement
 type RequirementEnforcementStatus string
 
@@ -48,6 +72,19 @@ const (
 	EnforcementStatusGuidance RequirementEnforcementStatus = "guidance"
 )
 
+// RequirementEnforcementStatuses lists the valid RequirementEnforcementStatus values.
+var RequirementEnforcementStatuses = registerEnum(EnforcementStatusEnforced, EnforcementStatusUpcoming, EnforcementStatusProposed, EnforcementStatusGuidance)
+
+// Valid reports whether s is a known requirement enforcement status.
+func (s RequirementEnforcementStatus) Valid() bool {
+	return slices.Contains(RequirementEnforcementStatuses, s)
+}
+
+// UnmarshalJSON decodes a requirement enforcement status, rejecting unknown values with an *EnumError.
+func (s *RequirementEnforcementStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, RequirementEnforcementStatuses)
+}
+
 // ProviderStatus represents the overall readiness status of a provider
 type ProviderStatus string
 
@@ -58,6 +95,17 @@ const (
 	ProviderStatusNotViable ProviderStatus = "not-viable"
 )
 
+// ProviderStatuses lists the valid ProviderStatus values.
+var ProviderStatuses = registerEnum(ProviderStatusReady, ProviderStatusPartial, ProviderStatusPlanned, ProviderStatusNotViable)
+
+// Valid reports whether s is a known provider status.
+func (s ProviderStatus) Valid() bool { return slices.Contains(ProviderStatuses, s) }
+
+// UnmarshalJSON decodes a provider status, rejecting unknown values with an *EnumError.
+func (s *ProviderStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, ProviderStatuses)
+}
+
 // RiskLevel represents the risk level for a segment
 type RiskLevel string
 
@@ -68,6 +116,17 @@ const (
 	RiskLow      RiskLevel = "low"
 )
 
+// RiskLevels lists the valid RiskLevel values.
+var RiskLevels = registerEnum(RiskCritical, RiskHigh, RiskMedium, RiskLow)
+
+// Valid reports whether l is a known risk level.
+func (l RiskLevel) Valid() bool { return slices.Contains(RiskLevels, l) }
+
+// UnmarshalJSON decodes a risk level, rejecting unknown values with an *EnumError.
+func (l *RiskLevel) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, l, RiskLevels)
+}
+
 // MarketSegment represents a market segment for compliance analysis
 type MarketSegment struct {
 	ID                    string                       `json:"id"`
@@ -0,0 +1,229 @@
+package comply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Extensions holds team-specific metadata on an entity, such as internal
+// ticket IDs or owners. It is saved under "extensions" as is; the library
+// does not interpret it, but ExtensionValidators can check it.
+type Extensions map[string]any
+
+// unknownFields holds the JSON fields of an entity that its Go type does not
+// know, such as fields added by a newer version, so that they survive a load
+// and save.
+type unknownFields map[string]json.RawMessage
+
+// unmarshalKnown decodes data into v, a pointer to a method-less copy of the
+// type named typeName, and returns the fields of data that v does not know.
+func unmarshalKnown(data []byte, v any, typeName string) (unknownFields, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Struct == reflect.TypeOf(v).Elem().Name() {
+			typeErr.Struct = typeName
+		}
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return nil, nil
+	}
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	var unknown unknownFields
+	for name, raw := range fields {
+		if !known[strings.ToLower(name)] {
+			if unknown == nil {
+				unknown = make(unknownFields)
+			}
+			unknown[name] = raw
+		}
+	}
+	return unknown, nil
+}
+
+// marshalKnown encodes v and appends the unknown fields, sorted by name.
+func marshalKnown(v any, unknown unknownFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 || len(data) < 2 || data[len(data)-1] != '}' {
+		return data, err
+	}
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, name := range names {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(unknown[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalEmbedded encodes embedded followed by the fields of extra. Types
+// that embed a type with a MarshalJSON method need it, since the promoted
+// method would encode only the embedded value.
+func marshalEmbedded(embedded, extra any) ([]byte, error) {
+	data, err := json.Marshal(embedded)
+	if err != nil {
+		return nil, err
+	}
+	more, err := json.Marshal(extra)
+	if err != nil || len(more) <= 2 {
+		return data, err
+	}
+	if len(data) <= 2 {
+		return more, nil
+	}
+	return append(append(data[:len(data)-1:len(data)-1], ','), more[1:]...), nil
+}
+
+var fieldNameCache sync.Map // reflect.Type -> map[string]bool
+
+// jsonFieldNames returns the lower-cased JSON field names of a struct type,
+// matching encoding/json's case-insensitive decoding.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := fieldNameCache.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	fieldNameCache.Store(t, names)
+	return names
+}
+
+// ExtensionValidator checks the extensions of one entity of the given kind.
+// Kind, EntityID and Rule of the returned diagnostics may be left empty:
+// they default to the entity, RuleInvalidExtension and error severity, and
+// Field is prefixed with "extensions.".
+type ExtensionValidator func(kind EntityKind, id string, ext Extensions) []Diagnostic
+
+// RequireExtensions returns an ExtensionValidator that reports entities of
+// kind missing any of the extension keys.
+func RequireExtensions(kind EntityKind, keys ...string) ExtensionValidator {
+	return func(k EntityKind, id string, ext Extensions) []Diagnostic {
+		if k != kind {
+			return nil
+		}
+		var diags []Diagnostic
+		for _, key := range keys {
+			if v, ok := ext[key]; !ok || v == nil || v == "" {
+				diags = append(diags, Diagnostic{Field: key, Message: "required extension is missing"})
+			}
+		}
+		return diags
+	}
+}
+
+// ParseRequiredExtensions parses "kind.key" specs, such as "mapping.owner",
+// into ExtensionValidators.
+func ParseRequiredExtensions(specs []string) ([]ExtensionValidator, error) {
+	var validators []ExtensionValidator
+	for _, spec := range specs {
+		kind, key, ok := strings.Cut(spec, ".")
+		if !ok || key == "" || !slices.Contains(extensionKinds, EntityKind(kind)) {
+			return nil, fmt.Errorf("invalid required extension %q (want kind.key, kind one of %s)", spec, joinKinds(extensionKinds))
+		}
+		validators = append(validators, RequireExtensions(EntityKind(kind), key))
+	}
+	return validators, nil
+}
+
+// extensionKinds are the entity kinds that carry Extensions.
+var extensionKinds = []EntityKind{
+	KindJurisdiction, KindRegulation, KindSection, KindRequirement, KindRegulatedEntity,
+	KindSolution, KindZoneAssignment, KindMapping, KindEnforcementAssessment,
+}
+
+func joinKinds(kinds []EntityKind) string {
+	s := make([]string, len(kinds))
+	for i, k := range kinds {
+		s[i] = string(k)
+	}
+	return strings.Join(s, ", ")
+}
+
+// validateExtensions runs the validators on the extensions of every entity.
+func (cf *ComplianceFramework) validateExtensions(vr *ValidationReport, validators []ExtensionValidator) {
+	if len(validators) == 0 {
+		return
+	}
+	check := func(kind EntityKind, id string, ext Extensions) {
+		for _, validate := range validators {
+			for _, d := range validate(kind, id, ext) {
+				if d.Kind == "" {
+					d.Kind = kind
+				}
+				if d.EntityID == "" {
+					d.EntityID = id
+				}
+				if d.Rule == "" {
+					d.Rule = RuleInvalidExtension
+				}
+				if d.Severity == "" {
+					d.Severity = DiagnosticError
+				}
+				if !strings.HasPrefix(d.Field, "extensions") {
+					d.Field = strings.TrimSuffix("extensions."+d.Field, ".")
+				}
+				vr.Diagnostics = append(vr.Diagnostics, d)
+			}
+		}
+	}
+
+	for _, j := range cf.Jurisdictions {
+		check(KindJurisdiction, j.ID, j.Extensions)
+	}
+	for _, r := range cf.Regulations {
+		check(KindRegulation, r.ID, r.Extensions)
+		for _, s := range r.Sections {
+			check(KindSection, s.ID, s.Extensions)
+		}
+		for _, e := range r.RegulatedEntities {
+			check(KindRegulatedEntity, e.ID, e.Extensions)
+		}
+	}
+	for _, r := range cf.Requirements {
+		check(KindRequirement, r.ID, r.Extensions)
+	}
+	for _, e := range cf.RegulatedEntities {
+		check(KindRegulatedEntity, e.ID, e.Extensions)
+	}
+	for _, s := range cf.Solutions {
+		check(KindSolution, s.ID, s.Extensions)
+	}
+	for _, za := range cf.ZoneAssignments {
+		check(KindZoneAssignment, za.ID, za.Extensions)
+	}
+	for _, m := range cf.Mappings {
+		check(KindMapping, m.ID, m.Extensions)
+	}
+	for _, ea := range cf.EnforcementAssessments {
+		check(KindEnforcementAssessment, ea.ID, ea.Extensions)
+	}
+}
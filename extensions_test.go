@@ -0,0 +1,172 @@
+package comply
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestUnknownFieldsRoundTrip(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.json": {Data: []byte(`{"name": "Test", "version": "1.0", "status": "draft", "author": "Research"}`)},
+		"requirements.json": {Data: []byte(`[{
+			"id": "REQ-1", "regulationId": "EU-GDPR", "name": "Encryption",
+			"extensions": {"ticket": "COMP-42", "owner": "security"},
+			"reviewCycle": {"months": 12}
+		}]`)},
+		"mappings.yaml": {Data: []byte("- id: M1\n  solutionId: aws\n  ID2: kept\n  sprint: 2026\n")},
+	}
+
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	if got := cf.Requirements[0].Extensions["ticket"]; got != "COMP-42" {
+		t.Errorf("expected extension ticket, got %v", got)
+	}
+
+	out := MapFSWriter{}
+	if err := SaveFramework(cf, out); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	for name, want := range map[string][]string{
+		"framework.json":    {`"status": "draft"`, `"author": "Research"`},
+		"requirements.json": {`"ticket": "COMP-42"`, `"reviewCycle": {`, `"months": 12`},
+		"mappings.json":     {`"ID2": "kept"`, `"sprint": 2026`},
+	} {
+		data := string(out[name])
+		for _, w := range want {
+			if !strings.Contains(data, w) {
+				t.Errorf("expected %s to contain %s, got:\n%s", name, w, data)
+			}
+		}
+	}
+
+	reloaded, err := LoadFrameworkFS(out)
+	if err != nil {
+		t.Fatalf("reloading failed: %v", err)
+	}
+	if d := Diff(cf, reloaded); !d.Empty() {
+		t.Errorf("expected no changes after round trip, got:\n%s", d.Text())
+	}
+}
+
+func TestNestedUnknownFieldsRoundTrip(t *testing.T) {
+	fsys := MapFSWriter{
+		"requirements.json": []byte(`[{
+			"id": "REQ-1", "regulationId": "EU-GDPR", "name": "Encryption",
+			"applicability": {"sectors": ["energy"], "threshold": "250 staff"},
+			"externalRefs": [{"type": "url", "value": "https://example.org", "archived": true}]
+		}]`),
+		"solutions.json":   []byte(`[{"id": "ovh", "name": "OVHcloud", "ownershipStructure": {"euOwnershipPercent": 100, "auditedBy": "KPMG"}}]`),
+		"enforcement.json": []byte(`[{"id": "E1", "jurisdictionId": "FR", "recentActions": [{"date": "2025-01-01", "entity": "X", "description": "Fine", "appealed": "yes"}]}]`),
+	}
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+
+	out := MapFSWriter{}
+	if err := SaveFramework(cf, out); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	for name, want := range map[string][]string{
+		"requirements.json": {`"threshold": "250 staff"`, `"archived": true`},
+		"solutions.json":    {`"auditedBy": "KPMG"`},
+		"enforcement.json":  {`"appealed": "yes"`},
+	} {
+		data := string(out[name])
+		for _, w := range want {
+			if !strings.Contains(data, w) {
+				t.Errorf("expected %s to contain %s, got:\n%s", name, w, data)
+			}
+		}
+	}
+}
+
+func TestMarshalKnownEmptyObject(t *testing.T) {
+	var j Jurisdiction
+	if err := json.Unmarshal([]byte(`{"x-note": "a"}`), &j); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":"","name":"","type":"","x-note":"a"}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestMarshalEmbeddedWrappers(t *testing.T) {
+	var m RequirementMapping
+	if err := json.Unmarshal([]byte(`{"id": "M1", "solutionId": "aws", "sprint": 3}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		v    any
+		want []string
+	}{
+		{ResolvedMapping{RequirementMapping: m, ResolvedFor: "FR", SourceJurisdictionID: "EU", Inherited: true},
+			[]string{`"id":"M1"`, `"sprint":3`, `"resolvedFor":"FR"`, `"sourceJurisdictionId":"EU"`, `"inherited":true`}},
+		{ResolvedZoneAssignment{ZoneAssignment: ZoneAssignment{ID: "Z1"}, ResolvedFor: "FR"},
+			[]string{`"id":"Z1"`, `"resolvedFor":"FR"`, `"inherited":false`}},
+		{ResolvedEnforcementAssessment{EnforcementAssessment: EnforcementAssessment{ID: "E1"}, ResolvedFor: "FR"},
+			[]string{`"id":"E1"`, `"resolvedFor":"FR"`}},
+		{DerivedZoneAssignment{ZoneAssignment: ZoneAssignment{ID: "Z2"}, RuleID: "R1"},
+			[]string{`"id":"Z2"`, `"ruleId":"R1"`}},
+	} {
+		data, err := json.Marshal(c.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range c.want {
+			if !strings.Contains(string(data), w) {
+				t.Errorf("expected %T JSON to contain %s, got %s", c.v, w, data)
+			}
+		}
+	}
+}
+
+func TestValidateWithExtensions(t *testing.T) {
+	cf := &ComplianceFramework{
+		Solutions: []Solution{{ID: "aws", Extensions: Extensions{"owner": "cloud"}}},
+		Mappings: []RequirementMapping{
+			{ID: "M1", SolutionID: "aws", Extensions: Extensions{"owner": "alice", "ticket": "bad"}},
+			{ID: "M2", SolutionID: "aws"},
+		},
+	}
+	ticketFormat := func(kind EntityKind, id string, ext Extensions) []Diagnostic {
+		if s, ok := ext["ticket"].(string); ok && !strings.HasPrefix(s, "COMP-") {
+			return []Diagnostic{{Severity: DiagnosticWarning, Field: "ticket", Value: s, Message: "ticket must start with COMP-"}}
+		}
+		return nil
+	}
+	required, err := ParseRequiredExtensions([]string{"mapping.owner"})
+	if err != nil {
+		t.Fatalf("ParseRequiredExtensions failed: %v", err)
+	}
+
+	report := cf.ValidateWith(append(required, ticketFormat)...)
+	var got []string
+	for _, d := range report.Diagnostics {
+		if d.Rule == RuleInvalidExtension {
+			got = append(got, d.String())
+		}
+	}
+	want := []string{
+		`warning mapping M1.extensions.ticket: ticket must start with COMP- "bad" [invalid-extension]`,
+		`error mapping M2.extensions.owner: required extension is missing [invalid-extension]`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected diagnostics:\n%s", strings.Join(got, "\n"))
+	}
+	if len(cf.Validate().Diagnostics) != len(report.Diagnostics)-2 {
+		t.Error("expected Validate to run no extension validators")
+	}
+
+	if _, err := ParseRequiredExtensions([]string{"widget.owner"}); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
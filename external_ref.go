@@ -12,8 +12,23 @@ const (
 
 // ExternalRef represents a reference to an external resource.
 type ExternalRef struct {
-	Type  ExternalRefType `json:"type"`
-	Value string          `json:"value"`
-	Name  string          `json:"name,omitempty"`
-	Notes string          `json:"notes,omitempty"`
+	Type    ExternalRefType `json:"type"`
+	Value   string          `json:"value"`
+	Name    string          `json:"name,omitempty"`
+	Notes   string          `json:"notes,omitempty"`
+	unknown unknownFields
+}
+
+// UnmarshalJSON decodes an external reference, keeping the fields it does not know.
+func (r *ExternalRef) UnmarshalJSON(data []byte) error {
+	type plain ExternalRef
+	var err error
+	r.unknown, err = unmarshalKnown(data, (*plain)(r), "ExternalRef")
+	return err
+}
+
+// MarshalJSON encodes an external reference, including the unknown fields it was decoded with.
+func (r ExternalRef) MarshalJSON() ([]byte, error) {
+	type plain ExternalRef
+	return marshalKnown(plain(r), r.unknown)
 }
@@ -0,0 +1,93 @@
+package comply
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Format is a file format for framework data.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath returns FormatYAML for .yaml and .yml files and FormatJSON otherwise.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// extensions returns the file extensions of the format, preferred first.
+func (f Format) extensions() []string {
+	if f == FormatYAML {
+		return []string{".yaml", ".yml"}
+	}
+	return []string{".json"}
+}
+
+// ParseFormat parses a format name.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "json", "":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("unknown format %q (allowed: json, yaml)", s)
+}
+
+// decodeFile decodes a JSON or YAML file, chosen by its extension.
+func (l *Loader) decodeFile(file string, data []byte, v any) error {
+	if FormatFromPath(file) == FormatYAML {
+		j, err := yamlToJSON(data, reflect.TypeOf(v))
+		if err != nil {
+			return fmt.Errorf("unmarshaling YAML from %s: %w", file, err)
+		}
+		data = j
+	}
+	return l.decode(file, data, v)
+}
+
+// encodeFile encodes v in the format. For YAML, comments are carried over
+// from existing, the previous content of the file.
+func encodeFile(format Format, v any, existing []byte) ([]byte, error) {
+	data, err := marshalJSON(v, true)
+	if err != nil || format != FormatYAML {
+		return data, err
+	}
+	data, err = jsonToYAML(data, existing)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling YAML: %w", err)
+	}
+	return data, nil
+}
+
+// findFile returns the name of the file for base in fsys, trying the JSON and
+// YAML extensions. It returns "" if none exists and an error if several do.
+func findFile(fsys fs.FS, base string) (string, error) {
+	var found []string
+	for _, ext := range append(FormatJSON.extensions(), FormatYAML.extensions()...) {
+		if _, err := fs.Stat(fsys, base+ext); err == nil {
+			found = append(found, base+ext)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	if len(found) > 1 {
+		return "", fmt.Errorf("ambiguous %s: found %s", base, strings.Join(found, " and "))
+	}
+	if len(found) == 0 {
+		return "", nil
+	}
+	return found[0], nil
+}
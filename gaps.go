@@ -0,0 +1,224 @@
+package comply
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GapEntry is an applicable requirement that a solution does not fully meet.
+type GapEntry struct {
+	RequirementID        string              `json:"requirementId"`
+	Name                 string              `json:"name,omitempty"`
+	RegulationID         string              `json:"regulationId"`
+	Category             string              `json:"category,omitempty"`
+	Severity             RequirementSeverity `json:"severity,omitempty"`
+	Unmapped             bool                `json:"unmapped,omitempty"`
+	ComplianceLevel      ComplianceLevel     `json:"complianceLevel,omitempty"` // empty when unmapped
+	MappingID            string              `json:"mappingId,omitempty"`
+	SourceJurisdictionID string              `json:"sourceJurisdictionId,omitempty"` // jurisdiction the mapping is inherited from
+	Conditions           string              `json:"conditions,omitempty"`
+	ETA                  string              `json:"eta,omitempty"`
+	Notes                string              `json:"notes,omitempty"`
+	NeedsReview          bool                `json:"needsReview,omitempty"` // applicability depends on conditions to review
+}
+
+// Status returns the compliance level, or "unmapped".
+func (g GapEntry) Status() string {
+	if g.Unmapped {
+		return "unmapped"
+	}
+	return string(g.ComplianceLevel)
+}
+
+// GapAnalysisResult lists what a solution lacks in a jurisdiction.
+type GapAnalysisResult struct {
+	SolutionID     string                   `json:"solutionId"`
+	SolutionName   string                   `json:"solutionName,omitempty"`
+	JurisdictionID string                   `json:"jurisdictionId"`
+	Profile        *OrganizationProfile     `json:"profile,omitempty"`
+	Applicable     int                      `json:"applicable"` // applicable requirements
+	Compliant      int                      `json:"compliant"`
+	Zones          []ResolvedZoneAssignment `json:"zones,omitempty"` // effective zones relevant to the profile
+	Gaps           []GapEntry               `json:"gaps"`
+}
+
+// gapOrder ranks compliance levels from worst to best for sorting gaps of equal severity.
+var gapOrder = map[ComplianceLevel]int{
+	ComplianceBanned:      0,
+	ComplianceNone:        1,
+	"":                    2, // unmapped
+	CompliancePartial:     3,
+	ComplianceConditional: 4,
+}
+
+// GapAnalysis lists the requirements that apply to a solution in a
+// jurisdiction but are unmapped, partial, conditional, non-compliant or
+// banned, sorted by severity. Mappings are resolved with inheritance (see
+// EffectiveMappings). When profile is set, only requirements that apply to the
+// profile in the jurisdiction are considered (see Applicability); otherwise
+// all requirements of ApplicableRegulations are.
+func (cf *ComplianceFramework) GapAnalysis(solutionID, jurisdictionID string, profile *OrganizationProfile) (*GapAnalysisResult, error) {
+	sol := cf.GetSolution(solutionID)
+	if sol == nil {
+		return nil, fmt.Errorf("unknown solution %q", solutionID)
+	}
+	if cf.GetJurisdiction(jurisdictionID) == nil {
+		return nil, fmt.Errorf("unknown jurisdiction %q", jurisdictionID)
+	}
+
+	result := &GapAnalysisResult{
+		SolutionID:     sol.ID,
+		SolutionName:   sol.Name,
+		JurisdictionID: jurisdictionID,
+		Profile:        profile,
+		Gaps:           []GapEntry{},
+	}
+
+	var requirements []*Requirement
+	var entityTypes []string
+	review := make(map[string]bool)
+	if profile != nil {
+		scoped := *profile
+		scoped.JurisdictionIDs = []string{jurisdictionID}
+		applicability := cf.Applicability(&scoped)
+		entityTypes = applicability.EntityTypes
+		for _, ra := range applicability.Requirements {
+			if r := cf.GetRequirement(ra.RequirementID); r != nil {
+				requirements = append(requirements, r)
+				review[r.ID] = ra.NeedsReview
+			}
+		}
+	} else {
+		regs := make(map[string]bool)
+		for _, reg := range cf.ApplicableRegulations(jurisdictionID) {
+			regs[reg.ID] = true
+		}
+		for i := range cf.Requirements {
+			if regs[cf.Requirements[i].RegulationID] {
+				requirements = append(requirements, &cf.Requirements[i])
+			}
+		}
+	}
+
+	mappings := make(map[string]ResolvedMapping)
+	for _, m := range cf.EffectiveMappings(jurisdictionID) {
+		if m.SolutionID == solutionID {
+			mappings[m.RequirementID] = m
+		}
+	}
+
+	for _, r := range requirements {
+		result.Applicable++
+		m, ok := mappings[r.ID]
+		if ok && m.ComplianceLevel == ComplianceFull {
+			result.Compliant++
+			continue
+		}
+		var mp *ResolvedMapping
+		if ok {
+			mp = &m
+		}
+		gap := newGapEntry(r, mp)
+		gap.NeedsReview = review[r.ID]
+		result.Gaps = append(result.Gaps, gap)
+	}
+
+	sortGaps(result.Gaps)
+
+	for _, z := range cf.EffectiveZoneAssignments(jurisdictionID) {
+		if z.SolutionID != solutionID {
+			continue
+		}
+		if profile != nil {
+			if z.DataCategory != "" && z.DataCategory != "general" && !slices.Contains(normalizeAll(profile.DataTypes), normalize(z.DataCategory)) {
+				continue
+			}
+			if z.EntityType != "" && !slices.Contains(entityTypes, normalize(z.EntityType)) {
+				continue
+			}
+		}
+		result.Zones = append(result.Zones, z)
+	}
+
+	return result, nil
+}
+
+// newGapEntry builds a gap for a requirement; m is nil when unmapped.
+func newGapEntry(r *Requirement, m *ResolvedMapping) GapEntry {
+	gap := GapEntry{
+		RequirementID: r.ID,
+		Name:          r.Name,
+		RegulationID:  r.RegulationID,
+		Category:      r.Category,
+		Severity:      r.Severity,
+		Unmapped:      m == nil,
+	}
+	if m != nil {
+		gap.ComplianceLevel = m.ComplianceLevel
+		gap.MappingID = m.ID
+		gap.Conditions = m.Conditions
+		gap.ETA = m.ETA
+		gap.Notes = m.Notes
+		if m.Inherited {
+			gap.SourceJurisdictionID = m.SourceJurisdictionID
+		}
+	}
+	return gap
+}
+
+// sortGaps orders gaps by severity, then from banned to conditional.
+func sortGaps(gaps []GapEntry) {
+	slices.SortStableFunc(gaps, func(a, b GapEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Severity.Rank(), b.Severity.Rank()),
+			cmp.Compare(gapOrder[a.ComplianceLevel], gapOrder[b.ComplianceLevel]),
+		)
+	})
+}
+
+// Markdown renders the gap analysis as a Markdown report.
+func (g *GapAnalysisResult) Markdown() string {
+	var sb strings.Builder
+	name := g.SolutionID
+	if g.SolutionName != "" {
+		name = g.SolutionName
+	}
+	fmt.Fprintf(&sb, "# Gap Analysis: %s in %s\n\n", name, g.JurisdictionID)
+	if g.Profile != nil && g.Profile.Name != "" {
+		fmt.Fprintf(&sb, "Profile: %s\n\n", g.Profile.Name)
+	}
+	fmt.Fprintf(&sb, "- Applicable requirements: %d\n", g.Applicable)
+	fmt.Fprintf(&sb, "- Compliant: %d\n", g.Compliant)
+	fmt.Fprintf(&sb, "- Gaps: %d\n", len(g.Gaps))
+
+	if len(g.Zones) > 0 {
+		sb.WriteString("\n## Zones\n\n| Zone | Data Category | Entity Type | Rationale |\n|------|---------------|-------------|-----------|\n")
+		for _, z := range g.Zones {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", z.Zone, z.DataCategory, z.EntityType, markdownCell(z.Rationale))
+		}
+	}
+
+	sb.WriteString("\n## Gaps\n\n")
+	if len(g.Gaps) == 0 {
+		sb.WriteString("No gaps found.\n")
+		return sb.String()
+	}
+	sb.WriteString("| Severity | Requirement | Regulation | Status | Conditions | ETA |\n|----------|-------------|------------|--------|------------|-----|\n")
+	for _, gap := range g.Gaps {
+		req := gap.RequirementID
+		if gap.Name != "" {
+			req += " " + gap.Name
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+			gap.Severity, markdownCell(req), gap.RegulationID, gap.Status(), markdownCell(gap.Conditions), gap.ETA)
+	}
+	return sb.String()
+}
+
+// markdownCell escapes a value for use in a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
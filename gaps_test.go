@@ -0,0 +1,94 @@
+package comply
+
+import (
+	"testing"
+)
+
+func gapsTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+		},
+		Regulations: []Regulation{
+			{ID: "EU-GDPR", JurisdictionID: "EU", Status: RegulationEnforceable},
+			{ID: "FR-SNC", JurisdictionID: "FR", Status: RegulationEnforceable},
+		},
+		Requirements: []Requirement{
+			{ID: "GDPR-01", RegulationID: "EU-GDPR", Severity: SeverityLow},
+			{ID: "GDPR-02", RegulationID: "EU-GDPR", Severity: SeverityHigh, Applicability: &Applicability{DataTypes: []string{"health-data"}}},
+			{ID: "SNC-01", RegulationID: "FR-SNC", Severity: SeverityCritical},
+			{ID: "SNC-02", RegulationID: "FR-SNC", Severity: SeverityCritical},
+			{ID: "SNC-03", RegulationID: "FR-SNC", Severity: SeverityMedium},
+		},
+		Solutions: []Solution{
+			{ID: "aws", JurisdictionIDs: []string{"EU"}},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "GDPR-01", SolutionID: "aws", JurisdictionIDs: []string{"EU"}, ComplianceLevel: CompliancePartial, Conditions: "SCCs required", ETA: "2026"},
+			{ID: "M2", RequirementID: "SNC-01", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: CompliancePartial},
+			{ID: "M3", RequirementID: "SNC-02", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceBanned},
+			{ID: "M4", RequirementID: "SNC-03", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceFull},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "aws", JurisdictionID: "FR", DataCategory: "essential-data", Zone: ZoneRed},
+			{ID: "Z2", SolutionID: "aws", JurisdictionID: "EU", DataCategory: "personal-data", Zone: ZoneYellow},
+		},
+	}
+}
+
+func TestGapAnalysis(t *testing.T) {
+	cf := gapsTestFramework()
+
+	result, err := cf.GapAnalysis("aws", "FR", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Applicable != 5 || result.Compliant != 1 {
+		t.Errorf("expected 5 applicable and 1 compliant, got %d and %d", result.Applicable, result.Compliant)
+	}
+
+	var order []string
+	for _, g := range result.Gaps {
+		order = append(order, g.RequirementID+":"+g.Status())
+	}
+	expected := []string{"SNC-02:banned", "SNC-01:partial", "GDPR-02:unmapped", "GDPR-01:partial"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected gaps %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected gaps %v, got %v", expected, order)
+			break
+		}
+	}
+
+	last := result.Gaps[len(result.Gaps)-1]
+	if last.Conditions != "SCCs required" || last.ETA != "2026" || last.SourceJurisdictionID != "EU" {
+		t.Errorf("expected inherited mapping details on GDPR-01 gap, got %+v", last)
+	}
+	if len(result.Zones) != 2 {
+		t.Errorf("expected both zones without a profile, got %v", result.Zones)
+	}
+}
+
+func TestGapAnalysisWithProfile(t *testing.T) {
+	cf := gapsTestFramework()
+
+	result, err := cf.GapAnalysis("aws", "FR", &OrganizationProfile{DataTypes: []string{"personal-data"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range result.Gaps {
+		if g.RequirementID == "GDPR-02" {
+			t.Error("expected health-data requirement not to apply to the profile")
+		}
+	}
+	if len(result.Zones) != 1 || result.Zones[0].ID != "Z2" {
+		t.Errorf("expected only the personal-data zone, got %v", result.Zones)
+	}
+
+	if _, err := cf.GapAnalysis("unknown", "FR", nil); err == nil {
+		t.Error("expected error for unknown solution")
+	}
+}
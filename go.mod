@@ -1,3 +1,5 @@
 module github.com/grokify/go-comply
 
 go 1.25.5
+
+require go.yaml.in/yaml/v3 v3.0.4
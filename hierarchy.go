@@ -0,0 +1,322 @@
+package comply
+
+import (
+	"slices"
+	"sort"
+)
+
+// JurisdictionHierarchy resolves parent/member relationships between jurisdictions.
+//
+// Edges come from both Jurisdiction.ParentID and Jurisdiction.MemberIDs, so a
+// country listed only in a region's MemberIDs still inherits from that region.
+// A jurisdiction may have more than one parent (e.g. EU and EEA).
+type JurisdictionHierarchy struct {
+	parents  map[string][]string
+	children map[string][]string
+}
+
+// NewJurisdictionHierarchy builds a hierarchy from a list of jurisdictions.
+func NewJurisdictionHierarchy(jurisdictions []Jurisdiction) *JurisdictionHierarchy {
+	h := &JurisdictionHierarchy{
+		parents:  make(map[string][]string),
+		children: make(map[string][]string),
+	}
+	for _, j := range jurisdictions {
+		if j.ParentID != "" {
+			h.link(j.ParentID, j.ID)
+		}
+		for _, memberID := range j.MemberIDs {
+			h.link(j.ID, memberID)
+		}
+	}
+	return h
+}
+
+// Hierarchy returns the jurisdiction hierarchy for the framework.
+func (cf *ComplianceFramework) Hierarchy() *JurisdictionHierarchy {
+	return NewJurisdictionHierarchy(cf.Jurisdictions)
+}
+
+func (h *JurisdictionHierarchy) link(parentID, childID string) {
+	if parentID == childID || slices.Contains(h.parents[childID], parentID) {
+		return
+	}
+	h.parents[childID] = append(h.parents[childID], parentID)
+	h.children[parentID] = append(h.children[parentID], childID)
+}
+
+// Parents returns the direct parents of a jurisdiction.
+func (h *JurisdictionHierarchy) Parents(id string) []string {
+	return append([]string(nil), h.parents[id]...)
+}
+
+// Children returns the direct members of a jurisdiction.
+func (h *JurisdictionHierarchy) Children(id string) []string {
+	return append([]string(nil), h.children[id]...)
+}
+
+// Ancestors returns all ancestors of a jurisdiction, nearest first.
+func (h *JurisdictionHierarchy) Ancestors(id string) []string {
+	return walk(id, h.parents)
+}
+
+// Descendants returns all descendants of a jurisdiction, nearest first.
+func (h *JurisdictionHierarchy) Descendants(id string) []string {
+	return walk(id, h.children)
+}
+
+// Distance returns the number of levels between a jurisdiction and one of its
+// ancestors: 0 if they are the same, 1 for a direct parent, and so on. It
+// returns -1 if ancestorID is neither id nor one of its ancestors.
+func (h *JurisdictionHierarchy) Distance(id, ancestorID string) int {
+	if id == ancestorID {
+		return 0
+	}
+	depth := map[string]int{id: 0}
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, p := range h.parents[cur] {
+			if _, seen := depth[p]; seen {
+				continue
+			}
+			depth[p] = depth[cur] + 1
+			if p == ancestorID {
+				return depth[p]
+			}
+			queue = append(queue, p)
+		}
+	}
+	return -1
+}
+
+// AppliesTo returns true if something scoped to scopeID also applies in
+// targetID, i.e. scopeID is targetID or one of its ancestors.
+func (h *JurisdictionHierarchy) AppliesTo(scopeID, targetID string) bool {
+	return h.Distance(targetID, scopeID) >= 0
+}
+
+// nearest returns the scope in scopeIDs closest to targetID and its distance,
+// or -1 if none of them apply.
+func (h *JurisdictionHierarchy) nearest(targetID string, scopeIDs []string) (string, int) {
+	best, bestDist := "", -1
+	for _, s := range scopeIDs {
+		if d := h.Distance(targetID, s); d >= 0 && (bestDist < 0 || d < bestDist) {
+			best, bestDist = s, d
+		}
+	}
+	return best, bestDist
+}
+
+// walk performs a breadth-first traversal over edges, guarding against cycles.
+func walk(id string, edges map[string][]string) []string {
+	var result []string
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[cur] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			result = append(result, next)
+			queue = append(queue, next)
+		}
+	}
+	return result
+}
+
+// unscopedDistance ranks mappings without jurisdictions below any scoped mapping.
+const unscopedDistance = 1 << 30
+
+// ResolvedMapping is a mapping that applies in a jurisdiction, possibly inherited from an ancestor.
+type ResolvedMapping struct {
+	RequirementMapping
+	ResolvedFor          string `json:"resolvedFor"`                    // jurisdiction the mapping was resolved for
+	SourceJurisdictionID string `json:"sourceJurisdictionId,omitempty"` // jurisdiction on the mapping that matched; empty if unscoped
+	Inherited            bool   `json:"inherited"`
+}
+
+// MarshalJSON encodes the mapping followed by how it was resolved.
+func (m ResolvedMapping) MarshalJSON() ([]byte, error) {
+	return marshalEmbedded(m.RequirementMapping, struct {
+		ResolvedFor          string `json:"resolvedFor"`
+		SourceJurisdictionID string `json:"sourceJurisdictionId,omitempty"`
+		Inherited            bool   `json:"inherited"`
+	}{m.ResolvedFor, m.SourceJurisdictionID, m.Inherited})
+}
+
+// ResolvedZoneAssignment is a zone assignment that applies in a jurisdiction, possibly inherited from an ancestor.
+type ResolvedZoneAssignment struct {
+	ZoneAssignment
+	ResolvedFor string `json:"resolvedFor"`
+	Inherited   bool   `json:"inherited"`
+}
+
+// MarshalJSON encodes the zone assignment followed by how it was resolved.
+func (z ResolvedZoneAssignment) MarshalJSON() ([]byte, error) {
+	return marshalEmbedded(z.ZoneAssignment, struct {
+		ResolvedFor string `json:"resolvedFor"`
+		Inherited   bool   `json:"inherited"`
+	}{z.ResolvedFor, z.Inherited})
+}
+
+// ResolvedEnforcementAssessment is an enforcement assessment that applies in a jurisdiction, possibly inherited from an ancestor.
+type ResolvedEnforcementAssessment struct {
+	EnforcementAssessment
+	ResolvedFor string `json:"resolvedFor"`
+	Inherited   bool   `json:"inherited"`
+}
+
+// MarshalJSON encodes the assessment followed by how it was resolved.
+func (a ResolvedEnforcementAssessment) MarshalJSON() ([]byte, error) {
+	return marshalEmbedded(a.EnforcementAssessment, struct {
+		ResolvedFor string `json:"resolvedFor"`
+		Inherited   bool   `json:"inherited"`
+	}{a.ResolvedFor, a.Inherited})
+}
+
+// candidate tracks the best record found so far for a cell.
+type candidate struct {
+	index    int
+	distance int
+	date     string
+}
+
+// beats reports whether c takes precedence over other for the same cell.
+//
+// The record scoped to the most specific jurisdiction wins: an exact match
+// beats a parent, a parent beats a grandparent, and a mapping with no
+// JurisdictionIDs (which applies everywhere) loses to any scoped mapping.
+// Ties at the same level go to the most recent AssessmentDate, then to the
+// record that appears first in the framework.
+func (c candidate) beats(other candidate) bool {
+	if c.distance != other.distance {
+		return c.distance < other.distance
+	}
+	if c.date != other.date {
+		return c.date > other.date
+	}
+	return c.index < other.index
+}
+
+// resolve picks the winning record per key and returns their indexes in framework order.
+func resolve(n int, key func(int) string, distance func(int) int, date func(int) string) []candidate {
+	best := make(map[string]candidate)
+	for i := 0; i < n; i++ {
+		d := distance(i)
+		if d < 0 {
+			continue
+		}
+		c := candidate{index: i, distance: d, date: date(i)}
+		k := key(i)
+		if cur, ok := best[k]; !ok || c.beats(cur) {
+			best[k] = c
+		}
+	}
+	result := make([]candidate, 0, len(best))
+	for _, c := range best {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].index < result[j].index })
+	return result
+}
+
+// EffectiveMappings returns the mappings that apply in a jurisdiction,
+// including mappings inherited from its ancestors and unscoped mappings.
+// Only the winning mapping for each requirement/solution cell is returned.
+func (cf *ComplianceFramework) EffectiveMappings(jurisdictionID string) []ResolvedMapping {
+	h := cf.Hierarchy()
+	ms := cf.Mappings
+	winners := resolve(len(ms),
+		func(i int) string { return ms[i].RequirementID + "|" + ms[i].SolutionID },
+		func(i int) int {
+			if len(ms[i].JurisdictionIDs) == 0 {
+				return unscopedDistance
+			}
+			_, d := h.nearest(jurisdictionID, ms[i].JurisdictionIDs)
+			return d
+		},
+		func(i int) string { return ms[i].AssessmentDate },
+	)
+
+	result := make([]ResolvedMapping, 0, len(winners))
+	for _, w := range winners {
+		m := ms[w.index]
+		rm := ResolvedMapping{RequirementMapping: m, ResolvedFor: jurisdictionID}
+		if w.distance != unscopedDistance {
+			rm.SourceJurisdictionID, _ = h.nearest(jurisdictionID, m.JurisdictionIDs)
+			rm.Inherited = w.distance > 0
+		}
+		result = append(result, rm)
+	}
+	return result
+}
+
+// EffectiveZoneAssignments returns the zone assignments that apply in a
+// jurisdiction, including assignments inherited from its ancestors. Only the
+// winning assignment for each solution/data category/entity type is returned.
+func (cf *ComplianceFramework) EffectiveZoneAssignments(jurisdictionID string) []ResolvedZoneAssignment {
+	h := cf.Hierarchy()
+	zas := cf.ZoneAssignments
+	winners := resolve(len(zas),
+		func(i int) string { return zas[i].SolutionID + "|" + zas[i].DataCategory + "|" + zas[i].EntityType },
+		func(i int) int { return h.Distance(jurisdictionID, zas[i].JurisdictionID) },
+		func(int) string { return "" },
+	)
+
+	result := make([]ResolvedZoneAssignment, 0, len(winners))
+	for _, w := range winners {
+		result = append(result, ResolvedZoneAssignment{
+			ZoneAssignment: zas[w.index],
+			ResolvedFor:    jurisdictionID,
+			Inherited:      w.distance > 0,
+		})
+	}
+	return result
+}
+
+// EffectiveEnforcementAssessments returns the enforcement assessments that
+// apply in a jurisdiction, including assessments inherited from its
+// ancestors. Only the winning assessment for each regulation/requirement is returned.
+func (cf *ComplianceFramework) EffectiveEnforcementAssessments(jurisdictionID string) []ResolvedEnforcementAssessment {
+	h := cf.Hierarchy()
+	eas := cf.EnforcementAssessments
+	winners := resolve(len(eas),
+		func(i int) string { return eas[i].RegulationID + "|" + eas[i].RequirementID },
+		func(i int) int { return h.Distance(jurisdictionID, eas[i].JurisdictionID) },
+		func(i int) string { return eas[i].AssessmentDate },
+	)
+
+	result := make([]ResolvedEnforcementAssessment, 0, len(winners))
+	for _, w := range winners {
+		result = append(result, ResolvedEnforcementAssessment{
+			EnforcementAssessment: eas[w.index],
+			ResolvedFor:           jurisdictionID,
+			Inherited:             w.distance > 0,
+		})
+	}
+	return result
+}
+
+// ApplicableRegulations returns the regulations in force in a jurisdiction:
+// those issued by the jurisdiction itself or by any of its ancestors.
+// Regulations are cumulative, so a national regulation never hides a
+// supranational one. Regulations without a jurisdiction apply everywhere.
+// Superseded regulations are excluded.
+func (cf *ComplianceFramework) ApplicableRegulations(jurisdictionID string) []Regulation {
+	h := cf.Hierarchy()
+	var result []Regulation
+	for _, r := range cf.Regulations {
+		if r.Status == RegulationSuperseded {
+			continue
+		}
+		if r.JurisdictionID == "" || h.AppliesTo(r.JurisdictionID, jurisdictionID) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
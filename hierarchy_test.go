@@ -0,0 +1,166 @@
+package comply
+
+import (
+	"slices"
+	"testing"
+)
+
+func hierarchyTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational, MemberIDs: []string{"FR", "DE", "AT"}},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+			{ID: "DE", Type: JurisdictionCountry, ParentID: "EU"},
+			{ID: "BY", Type: JurisdictionRegion, ParentID: "DE"},
+			{ID: "UK", Type: JurisdictionCountry},
+		},
+		Regulations: []Regulation{
+			{ID: "EU-GDPR", JurisdictionID: "EU", Status: RegulationEnforceable},
+			{ID: "FR-SNC", JurisdictionID: "FR", Status: RegulationEnforceable},
+			{ID: "EU-OLD", JurisdictionID: "EU", Status: RegulationSuperseded},
+			{ID: "UK-DPA", JurisdictionID: "UK", Status: RegulationEnforceable},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"EU"}, ComplianceLevel: CompliancePartial},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceBanned},
+			{ID: "M3", RequirementID: "REQ-002", SolutionID: "aws", ComplianceLevel: ComplianceFull},
+			{ID: "M4", RequirementID: "REQ-002", SolutionID: "aws", JurisdictionIDs: []string{"EU"}, ComplianceLevel: CompliancePartial},
+			{ID: "M5", RequirementID: "REQ-003", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, ComplianceLevel: ComplianceFull, AssessmentDate: "2025-01-01"},
+			{ID: "M6", RequirementID: "REQ-003", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, ComplianceLevel: ComplianceNone, AssessmentDate: "2025-06-01"},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "aws", JurisdictionID: "EU", Zone: ZoneYellow, DataCategory: "general"},
+			{ID: "Z2", SolutionID: "aws", JurisdictionID: "FR", Zone: ZoneRed, DataCategory: "general"},
+			{ID: "Z3", SolutionID: "aws", JurisdictionID: "EU", Zone: ZoneRed, DataCategory: "essential"},
+		},
+		EnforcementAssessments: []EnforcementAssessment{
+			{ID: "E1", JurisdictionID: "EU", RegulationID: "EU-GDPR", Likelihood: LikelihoodMedium},
+			{ID: "E2", JurisdictionID: "FR", RegulationID: "EU-GDPR", Likelihood: LikelihoodHigh},
+		},
+	}
+}
+
+func TestJurisdictionHierarchy(t *testing.T) {
+	h := hierarchyTestFramework().Hierarchy()
+
+	if got := h.Ancestors("BY"); !slices.Equal(got, []string{"DE", "EU"}) {
+		t.Errorf("expected ancestors [DE EU], got %v", got)
+	}
+	if got := h.Ancestors("AT"); !slices.Equal(got, []string{"EU"}) {
+		t.Errorf("expected member-only AT to inherit from EU, got %v", got)
+	}
+	desc := h.Descendants("EU")
+	for _, id := range []string{"FR", "DE", "AT", "BY"} {
+		if !slices.Contains(desc, id) {
+			t.Errorf("expected %s to be a descendant of EU, got %v", id, desc)
+		}
+	}
+	if d := h.Distance("BY", "EU"); d != 2 {
+		t.Errorf("expected distance 2 from BY to EU, got %d", d)
+	}
+	if !h.AppliesTo("EU", "FR") {
+		t.Error("expected EU to apply to FR")
+	}
+	if h.AppliesTo("FR", "EU") {
+		t.Error("expected FR not to apply to EU")
+	}
+	if h.AppliesTo("EU", "UK") {
+		t.Error("expected EU not to apply to UK")
+	}
+}
+
+func TestJurisdictionHierarchyCycle(t *testing.T) {
+	h := NewJurisdictionHierarchy([]Jurisdiction{
+		{ID: "A", ParentID: "B"},
+		{ID: "B", ParentID: "A"},
+	})
+	if got := h.Ancestors("A"); !slices.Equal(got, []string{"B"}) {
+		t.Errorf("expected ancestors [B], got %v", got)
+	}
+}
+
+func TestEffectiveMappings(t *testing.T) {
+	cf := hierarchyTestFramework()
+
+	byReq := func(ms []ResolvedMapping) map[string]ResolvedMapping {
+		result := make(map[string]ResolvedMapping)
+		for _, m := range ms {
+			result[m.RequirementID] = m
+		}
+		return result
+	}
+
+	fr := byReq(cf.EffectiveMappings("FR"))
+	if m := fr["REQ-001"]; m.ID != "M2" || m.Inherited {
+		t.Errorf("expected FR-specific M2 to override EU mapping, got %s (inherited=%v)", m.ID, m.Inherited)
+	}
+	if m := fr["REQ-002"]; m.ID != "M4" || !m.Inherited || m.SourceJurisdictionID != "EU" {
+		t.Errorf("expected M4 inherited from EU to beat unscoped M3, got %+v", m)
+	}
+	if _, ok := fr["REQ-003"]; ok {
+		t.Error("expected DE-only mapping not to apply in FR")
+	}
+
+	by := byReq(cf.EffectiveMappings("BY"))
+	if m := by["REQ-003"]; m.ID != "M6" {
+		t.Errorf("expected most recent DE mapping M6 to win, got %s", m.ID)
+	}
+	if m := by["REQ-001"]; m.ID != "M1" {
+		t.Errorf("expected EU mapping M1 to apply in BY, got %s", m.ID)
+	}
+
+	uk := byReq(cf.EffectiveMappings("UK"))
+	if len(uk) != 1 || uk["REQ-002"].ID != "M3" {
+		t.Errorf("expected only unscoped M3 in UK, got %v", uk)
+	}
+}
+
+func TestEffectiveZoneAssignments(t *testing.T) {
+	cf := hierarchyTestFramework()
+
+	zones := cf.EffectiveZoneAssignments("FR")
+	if len(zones) != 2 {
+		t.Fatalf("expected 2 effective zone assignments for FR, got %d", len(zones))
+	}
+	for _, z := range zones {
+		switch z.DataCategory {
+		case "general":
+			if z.ID != "Z2" || z.Inherited {
+				t.Errorf("expected FR override Z2 for general data, got %s", z.ID)
+			}
+		case "essential":
+			if z.ID != "Z3" || !z.Inherited {
+				t.Errorf("expected inherited Z3 for essential data, got %s", z.ID)
+			}
+		}
+	}
+
+	if zones := cf.EffectiveZoneAssignments("DE"); len(zones) != 2 || zones[0].ID != "Z1" {
+		t.Errorf("expected DE to inherit Z1 and Z3, got %v", zones)
+	}
+}
+
+func TestEffectiveEnforcementAssessments(t *testing.T) {
+	cf := hierarchyTestFramework()
+
+	fr := cf.EffectiveEnforcementAssessments("FR")
+	if len(fr) != 1 || fr[0].ID != "E2" {
+		t.Errorf("expected FR assessment E2 to override EU, got %v", fr)
+	}
+	de := cf.EffectiveEnforcementAssessments("DE")
+	if len(de) != 1 || de[0].ID != "E1" || !de[0].Inherited {
+		t.Errorf("expected DE to inherit E1, got %v", de)
+	}
+}
+
+func TestApplicableRegulations(t *testing.T) {
+	cf := hierarchyTestFramework()
+
+	var ids []string
+	for _, r := range cf.ApplicableRegulations("FR") {
+		ids = append(ids, r.ID)
+	}
+	if !slices.Equal(ids, []string{"EU-GDPR", "FR-SNC"}) {
+		t.Errorf("expected [EU-GDPR FR-SNC] for FR, got %v", ids)
+	}
+}
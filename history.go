@@ -0,0 +1,308 @@
+package comply
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// HistoryFileName is the conventional name of the mapping history log in a
+// framework directory. The loaders ignore it.
+const HistoryFileName = "history.jsonl"
+
+// MappingState is what a mapping said about a cell at one point in time.
+type MappingState struct {
+	MappingID       string          `json:"mappingId"`
+	ComplianceLevel ComplianceLevel `json:"complianceLevel,omitempty"`
+	Zone            ComplianceZone  `json:"zone,omitempty"`
+	Notes           string          `json:"notes,omitempty"`
+	Evidence        []string        `json:"evidence,omitempty"`
+	ETA             string          `json:"eta,omitempty"`
+	AssessmentDate  string          `json:"assessmentDate,omitempty"`
+	Confidence      ConfidenceLevel `json:"confidence,omitempty"`
+}
+
+// HistoryEntry records a change to the mapping of a (solution, requirement,
+// jurisdiction) cell, with the research that caused it. Previous is nil
+// when the cell was added and Current is nil when it was removed.
+type HistoryEntry struct {
+	Date           string           `json:"date"` // research date of the change
+	SolutionID     string           `json:"solutionId"`
+	RequirementID  string           `json:"requirementId"`
+	JurisdictionID string           `json:"jurisdictionId,omitempty"`
+	Type           ChangeType       `json:"type"`
+	Previous       *MappingState    `json:"previous,omitempty"`
+	Current        *MappingState    `json:"current,omitempty"`
+	Research       ResearchMetadata `json:"research"`
+	Source         string           `json:"source,omitempty"` // research file
+}
+
+// Cell renders the cell as "solution / requirement / jurisdiction".
+func (e HistoryEntry) Cell() string {
+	return VerdictChange{SolutionID: e.SolutionID, RequirementID: e.RequirementID, JurisdictionID: e.JurisdictionID}.Cell()
+}
+
+// String renders the entry, e.g. "2026-10-01 aws-commercial /
+// CTL-LEGAL-001 / FR: partial → compliant".
+func (e HistoryEntry) String() string {
+	var prev, cur MappingState
+	if e.Previous != nil {
+		prev = *e.Previous
+	}
+	if e.Current != nil {
+		cur = *e.Current
+	}
+	change := fmt.Sprintf("%s → %s", orNone(string(prev.ComplianceLevel)), orNone(string(cur.ComplianceLevel)))
+	if prev.Zone != cur.Zone {
+		change += fmt.Sprintf(", zone %s → %s", orNone(string(prev.Zone)), orNone(string(cur.Zone)))
+	}
+	if prev.ComplianceLevel == cur.ComplianceLevel && prev.Zone == cur.Zone {
+		change = "details changed"
+	}
+	return fmt.Sprintf("%s %s: %s", orNone(e.Date), e.Cell(), change)
+}
+
+// HistoryEntries records the cells whose mapping differs between the old and
+// the new mappings, attributed to research read from source. A cell counts
+// as changed when any field of its MappingState differs.
+func HistoryEntries(old, new []RequirementMapping, research ResearchMetadata, source string) []HistoryEntry {
+	oldCells, newCells := mappingStates(old), mappingStates(new)
+	entry := func(key [3]string, t ChangeType) HistoryEntry {
+		return HistoryEntry{Date: research.ResearchDate, SolutionID: key[0], RequirementID: key[1], JurisdictionID: key[2],
+			Type: t, Research: research, Source: source}
+	}
+
+	var entries []HistoryEntry
+	for key, o := range oldCells {
+		e := entry(key, ChangeRemoved)
+		e.Previous = o
+		if n, ok := newCells[key]; ok {
+			if reflect.DeepEqual(o, n) {
+				continue
+			}
+			e.Type, e.Current = ChangeModified, n
+		}
+		entries = append(entries, e)
+	}
+	for key, n := range newCells {
+		if _, ok := oldCells[key]; !ok {
+			e := entry(key, ChangeAdded)
+			e.Current = n
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, compareHistoryCells)
+	return entries
+}
+
+func mappingStates(mappings []RequirementMapping) map[[3]string]*MappingState {
+	states := make(map[[3]string]*MappingState)
+	for _, m := range mappings {
+		jurs := m.JurisdictionIDs
+		if len(jurs) == 0 {
+			jurs = []string{""}
+		}
+		for _, jur := range jurs {
+			key := [3]string{m.SolutionID, m.RequirementID, jur}
+			if _, ok := states[key]; !ok {
+				states[key] = &MappingState{MappingID: m.ID, ComplianceLevel: m.ComplianceLevel, Zone: m.Zone, Notes: m.Notes,
+					Evidence: m.Evidence, ETA: m.ETA, AssessmentDate: m.AssessmentDate, Confidence: m.Confidence}
+			}
+		}
+	}
+	return states
+}
+
+func compareHistoryCells(a, b HistoryEntry) int {
+	return cmp.Or(
+		cmp.Compare(a.SolutionID, b.SolutionID),
+		cmp.Compare(a.RequirementID, b.RequirementID),
+		cmp.Compare(a.JurisdictionID, b.JurisdictionID),
+	)
+}
+
+// MappingHistory is an append-only log of mapping changes, stored as JSON
+// Lines with one HistoryEntry per line, oldest first.
+type MappingHistory struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// LoadMappingHistory reads a history log. A missing file is an empty
+// history.
+func LoadMappingHistory(path string) (*MappingHistory, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &MappingHistory{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	h := &MappingHistory{}
+	n := 0
+	for line := range bytes.Lines(data) {
+		n++
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e HistoryEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		h.Entries = append(h.Entries, e)
+	}
+	return h, nil
+}
+
+// AppendMappingHistory appends entries to the history log at path, creating
+// it if needed, and returns the number appended. Entries already in the log,
+// with the same cell, current state and research version, are skipped, so
+// importing the same research twice records it once. Existing entries are
+// never rewritten.
+func AppendMappingHistory(path string, entries []HistoryEntry) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	h, err := LoadMappingHistory(path)
+	if err != nil {
+		return 0, err
+	}
+	logged := make(map[string]bool, len(h.Entries))
+	for _, e := range h.Entries {
+		logged[historyKey(e)] = true
+	}
+
+	var buf bytes.Buffer
+	n := 0
+	for _, e := range entries {
+		key := historyKey(e)
+		if logged[key] {
+			continue
+		}
+		logged[key] = true
+		data, err := json.Marshal(e)
+		if err != nil {
+			return 0, err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return 0, err
+	}
+	return n, f.Close()
+}
+
+// historyKey identifies an entry by its cell, current state and research
+// version. The state is compared in its JSON form, as it is logged.
+func historyKey(e HistoryEntry) string {
+	current, _ := json.Marshal(e.Current)
+	return strings.Join([]string{e.SolutionID, e.RequirementID, e.JurisdictionID, string(current), e.Research.Version}, "\x00")
+}
+
+// HistoryQuery selects history entries. Empty fields match any value.
+type HistoryQuery struct {
+	SolutionID     string
+	RequirementID  string
+	JurisdictionID string
+	Since          string // entries dated on or after this date or month
+}
+
+// Query returns the entries matching q, oldest first.
+func (h *MappingHistory) Query(q HistoryQuery) []HistoryEntry {
+	since := normalizeDate(q.Since)
+	var out []HistoryEntry
+	for _, e := range h.Entries {
+		if (q.SolutionID == "" || e.SolutionID == q.SolutionID) &&
+			(q.RequirementID == "" || e.RequirementID == q.RequirementID) &&
+			(q.JurisdictionID == "" || e.JurisdictionID == q.JurisdictionID) &&
+			(since == "" || datedSince(normalizeDate(e.Date), since)) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// datedSince reports whether the normalized date is on or after since,
+// comparing by prefix where either is a month: "2026-05-01" is since
+// "2026-05" and "2026-05" is since "2026-05-15". Undated entries are never
+// since a date.
+func datedSince(date, since string) bool {
+	if date == "" {
+		return false
+	}
+	return strings.HasPrefix(date, since) || strings.HasPrefix(since, date) || date >= since
+}
+
+// Cell returns the history of one cell, oldest first. An empty
+// jurisdictionID selects the mappings that apply in every jurisdiction.
+func (h *MappingHistory) Cell(solutionID, requirementID, jurisdictionID string) []HistoryEntry {
+	var out []HistoryEntry
+	for _, e := range h.Entries {
+		if e.SolutionID == solutionID && e.RequirementID == requirementID && e.JurisdictionID == jurisdictionID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Since returns the entries dated on or after date, oldest first. A month
+// such as "2026-05" includes the whole month.
+func (h *MappingHistory) Since(date string) []HistoryEntry {
+	return h.Query(HistoryQuery{Since: date})
+}
+
+// HistoryText renders entries as plain text, with the previous and current
+// mapping and the provenance of each change.
+func HistoryText(entries []HistoryEntry) string {
+	if len(entries) == 0 {
+		return "No history entries.\n"
+	}
+	var sb strings.Builder
+	for i, e := range entries {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s (%s)\n", e, e.Type)
+		writeState := func(label string, s *MappingState) {
+			if s == nil {
+				return
+			}
+			fmt.Fprintf(&sb, "  %-9s %s: %s, zone %s, assessed %s, confidence %s\n", label, s.MappingID,
+				orNone(string(s.ComplianceLevel)), orNone(string(s.Zone)), orNone(s.AssessmentDate), orNone(string(s.Confidence)))
+			for _, ev := range s.Evidence {
+				fmt.Fprintf(&sb, "            %s\n", ev)
+			}
+		}
+		writeState("previous", e.Previous)
+		writeState("current", e.Current)
+		var by []string
+		if e.Research.Researcher != "" {
+			by = append(by, "researcher "+e.Research.Researcher)
+		}
+		if e.Research.Version != "" {
+			by = append(by, "version "+e.Research.Version)
+		}
+		if e.Source != "" {
+			by = append(by, "source "+e.Source)
+		}
+		if len(by) > 0 {
+			fmt.Fprintf(&sb, "  %s\n", strings.Join(by, ", "))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+package comply
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMergeHistory(t *testing.T) {
+	existing := []RequirementMapping{
+		{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: CompliancePartial, Evidence: []string{"https://old"}},
+	}
+	ri := &ResearchInput{
+		Metadata: ResearchMetadata{ResearchDate: "2026-10-01", Researcher: "jdoe", Version: "2"},
+		Source:   "research.json",
+		Findings: []ResearchFinding{
+			{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, Status: "compliant", Evidence: []string{"https://new"}},
+		},
+	}
+
+	result := ri.Merge(existing)
+	if len(result.History) != 1 {
+		t.Fatalf("expected one changed cell, got %+v", result.History)
+	}
+	e := result.History[0]
+	if e.String() != "2026-10-01 aws / R1 / FR: partial → compliant" || e.Type != ChangeModified {
+		t.Errorf("unexpected entry %s (%s)", e, e.Type)
+	}
+	if e.Previous.MappingID != "M1" || e.Previous.Evidence[0] != "https://old" || e.Current.MappingID != "MAP-R1-aws-FR" {
+		t.Errorf("expected previous and current states, got %+v and %+v", e.Previous, e.Current)
+	}
+	if e.Research.Researcher != "jdoe" || e.Source != "research.json" {
+		t.Errorf("expected provenance, got %+v from %q", e.Research, e.Source)
+	}
+
+	text := HistoryText(result.History)
+	for _, s := range []string{"previous  M1: partial", "https://old", "researcher jdoe, version 2, source research.json"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("expected text to contain %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestMappingHistoryLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HistoryFileName)
+	h, err := LoadMappingHistory(path)
+	if err != nil || len(h.Entries) != 0 {
+		t.Fatalf("expected an empty history for a missing file, got %+v, %v", h, err)
+	}
+
+	v1 := []RequirementMapping{{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: CompliancePartial}}
+	v2 := []RequirementMapping{{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: ComplianceFull}}
+	v3 := []RequirementMapping{{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: ComplianceFull, Notes: "confirmed"}}
+	for _, entries := range [][]HistoryEntry{
+		HistoryEntries(nil, v1, ResearchMetadata{ResearchDate: "2025-01-15"}, "a.json"),
+		HistoryEntries(v1, v2, ResearchMetadata{ResearchDate: "2025-06-01"}, "b.json"),
+		HistoryEntries(v2, v3, ResearchMetadata{ResearchDate: "2026-02-01"}, "c.json"),
+	} {
+		if _, err := AppendMappingHistory(path, entries); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Importing the same research again appends nothing.
+	if n, err := AppendMappingHistory(path, HistoryEntries(v1, v2, ResearchMetadata{ResearchDate: "2025-06-01"}, "b.json")); err != nil || n != 0 {
+		t.Errorf("expected repeated entries to be skipped, appended %d, %v", n, err)
+	}
+
+	h, err = LoadMappingHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range h.Cell("aws", "R1", "FR") {
+		got = append(got, e.String()+" "+e.Source)
+	}
+	want := []string{
+		"2025-01-15 aws / R1 / FR: (none) → partial a.json",
+		"2025-06-01 aws / R1 / FR: partial → compliant b.json",
+		"2026-02-01 aws / R1 / FR: details changed c.json",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected cell history:\n%s", strings.Join(got, "\n"))
+	}
+	if since := h.Since("2025-06-01"); len(since) != 4 || since[0].JurisdictionID != "DE" {
+		t.Errorf("expected the DE and FR changes since June, got %+v", since)
+	}
+	if since := h.Since("2025-06"); len(since) != 4 {
+		t.Errorf("expected a month to include its first day, got %+v", since)
+	}
+	if since := h.Since("2025-06-15"); len(since) != 2 || since[0].Date != "2026-02-01" {
+		t.Errorf("expected only the February changes, got %+v", since)
+	}
+	undated := &MappingHistory{Entries: append(slices.Clone(h.Entries), HistoryEntry{SolutionID: "aws", RequirementID: "R2"})}
+	if since := undated.Since("2025-06"); len(since) != 4 {
+		t.Errorf("expected the undated entry to be left out, got %+v", since)
+	}
+	if q := h.Query(HistoryQuery{JurisdictionID: "DE"}); len(q) != 2 {
+		t.Errorf("expected two DE entries, got %+v", q)
+	}
+
+	if err := os.WriteFile(path, []byte("{}\nnot json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMappingHistory(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("expected an error naming line 2, got %v", err)
+	}
+}
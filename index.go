@@ -0,0 +1,512 @@
+package comply
+
+import (
+	"slices"
+	"sync"
+)
+
+// FrameworkIndex provides map-backed lookups over a ComplianceFramework.
+//
+// The index detects when a collection slice on the underlying framework is
+// replaced, appended to, or truncated and rebuilds itself on the next query.
+// Edits to fields of existing elements (e.g. cf.Mappings[i].SolutionID = "x")
+// cannot be detected; make those through the Upsert/Remove methods or call
+// Rebuild afterwards. A FrameworkIndex is safe for concurrent use as long as
+// the underlying framework is only mutated through the index.
+type FrameworkIndex struct {
+	mu    sync.RWMutex
+	cf    *ComplianceFramework
+	stamp frameworkStamp
+
+	jurisdictions          map[string]int
+	regulations            map[string]int
+	requirements           map[string]int
+	regulatedEntities      map[string]int
+	solutions              map[string]int
+	zoneAssignments        map[string]int
+	mappings               map[string]int
+	enforcementAssessments map[string]int
+
+	mappingsByRequirement     map[string][]int
+	mappingsBySolution        map[string][]int
+	mappingsByJurisdiction    map[string][]int
+	requirementsByRegulation  map[string][]int
+	requirementsByCategory    map[string][]int
+	requirementsBySeverity    map[RequirementSeverity][]int
+	zonesBySolution           map[string][]int
+	zonesByJurisdiction       map[string][]int
+	enforcementByJurisdiction map[string][]int
+}
+
+// sliceStamp identifies a slice by its backing array and length.
+type sliceStamp[T any] struct {
+	ptr *T
+	n   int
+}
+
+func stampOf[T any](s []T) sliceStamp[T] {
+	var p *T
+	if len(s) > 0 {
+		p = &s[0]
+	}
+	return sliceStamp[T]{ptr: p, n: len(s)}
+}
+
+type frameworkStamp struct {
+	jurisdictions          sliceStamp[Jurisdiction]
+	regulations            sliceStamp[Regulation]
+	requirements           sliceStamp[Requirement]
+	regulatedEntities      sliceStamp[RegulatedEntity]
+	solutions              sliceStamp[Solution]
+	zoneAssignments        sliceStamp[ZoneAssignment]
+	mappings               sliceStamp[RequirementMapping]
+	enforcementAssessments sliceStamp[EnforcementAssessment]
+}
+
+func stampFramework(cf *ComplianceFramework) frameworkStamp {
+	return frameworkStamp{
+		jurisdictions:          stampOf(cf.Jurisdictions),
+		regulations:            stampOf(cf.Regulations),
+		requirements:           stampOf(cf.Requirements),
+		regulatedEntities:      stampOf(cf.RegulatedEntities),
+		solutions:              stampOf(cf.Solutions),
+		zoneAssignments:        stampOf(cf.ZoneAssignments),
+		mappings:               stampOf(cf.Mappings),
+		enforcementAssessments: stampOf(cf.EnforcementAssessments),
+	}
+}
+
+// NewFrameworkIndex builds an index over the given framework.
+func NewFrameworkIndex(cf *ComplianceFramework) *FrameworkIndex {
+	ix := &FrameworkIndex{cf: cf}
+	ix.rebuild()
+	return ix
+}
+
+// Framework returns the underlying framework.
+func (ix *FrameworkIndex) Framework() *ComplianceFramework {
+	return ix.cf
+}
+
+// Rebuild recomputes all indexes from the underlying framework.
+func (ix *FrameworkIndex) Rebuild() {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.rebuild()
+}
+
+func (ix *FrameworkIndex) rebuild() {
+	ix.indexJurisdictions()
+	ix.indexRegulations()
+	ix.indexRequirements()
+	ix.indexRegulatedEntities()
+	ix.indexSolutions()
+	ix.indexZoneAssignments()
+	ix.indexMappings()
+	ix.indexEnforcementAssessments()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// rlock acquires a read lock, first rebuilding the index if the framework
+// slices have changed since it was built.
+func (ix *FrameworkIndex) rlock() {
+	ix.mu.RLock()
+	if ix.stamp == stampFramework(ix.cf) {
+		return
+	}
+	ix.mu.RUnlock()
+	ix.mu.Lock()
+	if ix.stamp != stampFramework(ix.cf) {
+		ix.rebuild()
+	}
+	ix.mu.Unlock()
+	ix.mu.RLock()
+}
+
+// lock acquires the write lock, rebuilding the index if it is stale.
+func (ix *FrameworkIndex) lock() {
+	ix.mu.Lock()
+	if ix.stamp != stampFramework(ix.cf) {
+		ix.rebuild()
+	}
+}
+
+func indexByID[T any](items []T, id func(*T) string) map[string]int {
+	m := make(map[string]int, len(items))
+	for i := range items {
+		key := id(&items[i])
+		if _, ok := m[key]; !ok { // first occurrence wins, matching the linear Get* methods
+			m[key] = i
+		}
+	}
+	return m
+}
+
+func groupBy[K comparable, T any](items []T, keys func(*T) []K) map[K][]int {
+	m := make(map[K][]int)
+	for i := range items {
+		for _, k := range keys(&items[i]) {
+			m[k] = append(m[k], i)
+		}
+	}
+	return m
+}
+
+func single[K comparable](k K) []K {
+	return []K{k}
+}
+
+func (ix *FrameworkIndex) indexJurisdictions() {
+	ix.jurisdictions = indexByID(ix.cf.Jurisdictions, func(j *Jurisdiction) string { return j.ID })
+}
+
+func (ix *FrameworkIndex) indexRegulations() {
+	ix.regulations = indexByID(ix.cf.Regulations, func(r *Regulation) string { return r.ID })
+}
+
+func (ix *FrameworkIndex) indexRequirements() {
+	reqs := ix.cf.Requirements
+	ix.requirements = indexByID(reqs, func(r *Requirement) string { return r.ID })
+	ix.requirementsByRegulation = groupBy(reqs, func(r *Requirement) []string { return single(r.RegulationID) })
+	ix.requirementsByCategory = groupBy(reqs, func(r *Requirement) []string { return single(r.Category) })
+	ix.requirementsBySeverity = groupBy(reqs, func(r *Requirement) []RequirementSeverity { return single(r.Severity) })
+}
+
+func (ix *FrameworkIndex) indexRegulatedEntities() {
+	ix.regulatedEntities = indexByID(ix.cf.RegulatedEntities, func(e *RegulatedEntity) string { return e.ID })
+}
+
+func (ix *FrameworkIndex) indexSolutions() {
+	ix.solutions = indexByID(ix.cf.Solutions, func(s *Solution) string { return s.ID })
+}
+
+func (ix *FrameworkIndex) indexZoneAssignments() {
+	zas := ix.cf.ZoneAssignments
+	ix.zoneAssignments = indexByID(zas, func(za *ZoneAssignment) string { return za.ID })
+	ix.zonesBySolution = groupBy(zas, func(za *ZoneAssignment) []string { return single(za.SolutionID) })
+	ix.zonesByJurisdiction = groupBy(zas, func(za *ZoneAssignment) []string { return single(za.JurisdictionID) })
+}
+
+func (ix *FrameworkIndex) indexMappings() {
+	ms := ix.cf.Mappings
+	ix.mappings = indexByID(ms, func(m *RequirementMapping) string { return m.ID })
+	ix.mappingsByRequirement = groupBy(ms, func(m *RequirementMapping) []string { return single(m.RequirementID) })
+	ix.mappingsBySolution = groupBy(ms, func(m *RequirementMapping) []string { return single(m.SolutionID) })
+	ix.mappingsByJurisdiction = groupBy(ms, func(m *RequirementMapping) []string { return uniqueStrings(m.JurisdictionIDs) })
+}
+
+func (ix *FrameworkIndex) indexEnforcementAssessments() {
+	eas := ix.cf.EnforcementAssessments
+	ix.enforcementAssessments = indexByID(eas, func(ea *EnforcementAssessment) string { return ea.ID })
+	ix.enforcementByJurisdiction = groupBy(eas, func(ea *EnforcementAssessment) []string { return single(ea.JurisdictionID) })
+}
+
+func uniqueStrings(values []string) []string {
+	if len(values) < 2 {
+		return values
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !slices.Contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func lookup[T any](items []T, ids map[string]int, id string) *T {
+	if i, ok := ids[id]; ok {
+		return &items[i]
+	}
+	return nil
+}
+
+func collect[T any](items []T, positions []int) []T {
+	if len(positions) == 0 {
+		return nil
+	}
+	result := make([]T, len(positions))
+	for i, p := range positions {
+		result[i] = items[p]
+	}
+	return result
+}
+
+// GetJurisdiction returns a jurisdiction by ID, or nil if not found.
+func (ix *FrameworkIndex) GetJurisdiction(id string) *Jurisdiction {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.Jurisdictions, ix.jurisdictions, id)
+}
+
+// GetRegulation returns a regulation by ID, or nil if not found.
+func (ix *FrameworkIndex) GetRegulation(id string) *Regulation {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.Regulations, ix.regulations, id)
+}
+
+// GetRequirement returns a requirement by ID, or nil if not found.
+func (ix *FrameworkIndex) GetRequirement(id string) *Requirement {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.Requirements, ix.requirements, id)
+}
+
+// GetRegulatedEntity returns a regulated entity by ID, or nil if not found.
+func (ix *FrameworkIndex) GetRegulatedEntity(id string) *RegulatedEntity {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.RegulatedEntities, ix.regulatedEntities, id)
+}
+
+// GetSolution returns a solution by ID, or nil if not found.
+func (ix *FrameworkIndex) GetSolution(id string) *Solution {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.Solutions, ix.solutions, id)
+}
+
+// GetZoneAssignment returns a zone assignment by ID, or nil if not found.
+func (ix *FrameworkIndex) GetZoneAssignment(id string) *ZoneAssignment {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.ZoneAssignments, ix.zoneAssignments, id)
+}
+
+// GetMapping returns a requirement mapping by ID, or nil if not found.
+func (ix *FrameworkIndex) GetMapping(id string) *RequirementMapping {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.Mappings, ix.mappings, id)
+}
+
+// GetEnforcementAssessment returns an enforcement assessment by ID, or nil if not found.
+func (ix *FrameworkIndex) GetEnforcementAssessment(id string) *EnforcementAssessment {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return lookup(ix.cf.EnforcementAssessments, ix.enforcementAssessments, id)
+}
+
+// GetMappingsForRequirement returns all mappings for a given requirement ID.
+func (ix *FrameworkIndex) GetMappingsForRequirement(requirementID string) []RequirementMapping {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Mappings, ix.mappingsByRequirement[requirementID])
+}
+
+// GetMappingsForSolution returns all mappings for a given solution ID.
+func (ix *FrameworkIndex) GetMappingsForSolution(solutionID string) []RequirementMapping {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Mappings, ix.mappingsBySolution[solutionID])
+}
+
+// GetMappingsForJurisdiction returns all mappings that explicitly list the
+// given jurisdiction ID. Mappings without jurisdictions are not included.
+func (ix *FrameworkIndex) GetMappingsForJurisdiction(jurisdictionID string) []RequirementMapping {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Mappings, ix.mappingsByJurisdiction[jurisdictionID])
+}
+
+// GetRequirementsByRegulation returns all requirements for a given regulation ID.
+func (ix *FrameworkIndex) GetRequirementsByRegulation(regulationID string) []Requirement {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Requirements, ix.requirementsByRegulation[regulationID])
+}
+
+// GetRequirementsByCategory returns all requirements in a given category.
+func (ix *FrameworkIndex) GetRequirementsByCategory(category string) []Requirement {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Requirements, ix.requirementsByCategory[category])
+}
+
+// GetRequirementsBySeverity returns all requirements with a given severity.
+func (ix *FrameworkIndex) GetRequirementsBySeverity(severity RequirementSeverity) []Requirement {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.Requirements, ix.requirementsBySeverity[severity])
+}
+
+// GetZoneAssignmentsForSolution returns all zone assignments for a given solution ID.
+func (ix *FrameworkIndex) GetZoneAssignmentsForSolution(solutionID string) []ZoneAssignment {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.ZoneAssignments, ix.zonesBySolution[solutionID])
+}
+
+// GetZoneAssignmentsForJurisdiction returns all zone assignments for a given jurisdiction ID.
+func (ix *FrameworkIndex) GetZoneAssignmentsForJurisdiction(jurisdictionID string) []ZoneAssignment {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.ZoneAssignments, ix.zonesByJurisdiction[jurisdictionID])
+}
+
+// GetEnforcementAssessmentsForJurisdiction returns all enforcement assessments for a given jurisdiction.
+func (ix *FrameworkIndex) GetEnforcementAssessmentsForJurisdiction(jurisdictionID string) []EnforcementAssessment {
+	ix.rlock()
+	defer ix.mu.RUnlock()
+	return collect(ix.cf.EnforcementAssessments, ix.enforcementByJurisdiction[jurisdictionID])
+}
+
+// upsert replaces the element with the same ID or appends it, returning the updated slice.
+func upsert[T any](items []T, ids map[string]int, id string, v T) []T {
+	if i, ok := ids[id]; ok {
+		items[i] = v
+		return items
+	}
+	return append(items, v)
+}
+
+// remove deletes the element with the given ID, returning the updated slice and
+// whether an element was removed.
+func remove[T any](items []T, ids map[string]int, id string) ([]T, bool) {
+	i, ok := ids[id]
+	if !ok {
+		return items, false
+	}
+	return slices.Delete(items, i, i+1), true
+}
+
+// UpsertJurisdiction adds or replaces a jurisdiction by ID.
+func (ix *FrameworkIndex) UpsertJurisdiction(j Jurisdiction) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.Jurisdictions = upsert(ix.cf.Jurisdictions, ix.jurisdictions, j.ID, j)
+	ix.indexJurisdictions()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveJurisdiction removes a jurisdiction by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveJurisdiction(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.Jurisdictions, ok = remove(ix.cf.Jurisdictions, ix.jurisdictions, id)
+	ix.indexJurisdictions()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertRegulation adds or replaces a regulation by ID.
+func (ix *FrameworkIndex) UpsertRegulation(r Regulation) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.Regulations = upsert(ix.cf.Regulations, ix.regulations, r.ID, r)
+	ix.indexRegulations()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveRegulation removes a regulation by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveRegulation(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.Regulations, ok = remove(ix.cf.Regulations, ix.regulations, id)
+	ix.indexRegulations()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertRequirement adds or replaces a requirement by ID.
+func (ix *FrameworkIndex) UpsertRequirement(r Requirement) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.Requirements = upsert(ix.cf.Requirements, ix.requirements, r.ID, r)
+	ix.indexRequirements()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveRequirement removes a requirement by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveRequirement(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.Requirements, ok = remove(ix.cf.Requirements, ix.requirements, id)
+	ix.indexRequirements()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertSolution adds or replaces a solution by ID.
+func (ix *FrameworkIndex) UpsertSolution(s Solution) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.Solutions = upsert(ix.cf.Solutions, ix.solutions, s.ID, s)
+	ix.indexSolutions()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveSolution removes a solution by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveSolution(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.Solutions, ok = remove(ix.cf.Solutions, ix.solutions, id)
+	ix.indexSolutions()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertZoneAssignment adds or replaces a zone assignment by ID.
+func (ix *FrameworkIndex) UpsertZoneAssignment(za ZoneAssignment) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.ZoneAssignments = upsert(ix.cf.ZoneAssignments, ix.zoneAssignments, za.ID, za)
+	ix.indexZoneAssignments()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveZoneAssignment removes a zone assignment by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveZoneAssignment(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.ZoneAssignments, ok = remove(ix.cf.ZoneAssignments, ix.zoneAssignments, id)
+	ix.indexZoneAssignments()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertMapping adds or replaces a requirement mapping by ID.
+func (ix *FrameworkIndex) UpsertMapping(m RequirementMapping) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.Mappings = upsert(ix.cf.Mappings, ix.mappings, m.ID, m)
+	ix.indexMappings()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveMapping removes a requirement mapping by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveMapping(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.Mappings, ok = remove(ix.cf.Mappings, ix.mappings, id)
+	ix.indexMappings()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
+
+// UpsertEnforcementAssessment adds or replaces an enforcement assessment by ID.
+func (ix *FrameworkIndex) UpsertEnforcementAssessment(ea EnforcementAssessment) {
+	ix.lock()
+	defer ix.mu.Unlock()
+	ix.cf.EnforcementAssessments = upsert(ix.cf.EnforcementAssessments, ix.enforcementAssessments, ea.ID, ea)
+	ix.indexEnforcementAssessments()
+	ix.stamp = stampFramework(ix.cf)
+}
+
+// RemoveEnforcementAssessment removes an enforcement assessment by ID and reports whether it existed.
+func (ix *FrameworkIndex) RemoveEnforcementAssessment(id string) bool {
+	ix.lock()
+	defer ix.mu.Unlock()
+	var ok bool
+	ix.cf.EnforcementAssessments, ok = remove(ix.cf.EnforcementAssessments, ix.enforcementAssessments, id)
+	ix.indexEnforcementAssessments()
+	ix.stamp = stampFramework(ix.cf)
+	return ok
+}
@@ -0,0 +1,139 @@
+package comply
+
+import (
+	"fmt"
+	"testing"
+)
+
+func indexTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Name: "European Union", Type: JurisdictionSupranational},
+			{ID: "FR", Name: "France", Type: JurisdictionCountry, ParentID: "EU"},
+		},
+		Requirements: []Requirement{
+			{ID: "REQ-001", RegulationID: "EU-GDPR", Category: "data-residency", Severity: SeverityCritical},
+			{ID: "REQ-002", RegulationID: "EU-GDPR", Category: "encryption", Severity: SeverityHigh},
+			{ID: "REQ-003", RegulationID: "EU-NIS2", Category: "encryption", Severity: SeverityHigh},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "aws", JurisdictionID: "FR", Zone: ZoneRed},
+			{ID: "Z2", SolutionID: "ovh", JurisdictionID: "FR", Zone: ZoneGreen},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"EU", "FR"}},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "ovh", JurisdictionIDs: []string{"FR"}},
+			{ID: "M3", RequirementID: "REQ-002", SolutionID: "aws"},
+		},
+	}
+}
+
+func TestFrameworkIndexLookups(t *testing.T) {
+	ix := NewFrameworkIndex(indexTestFramework())
+
+	if j := ix.GetJurisdiction("FR"); j == nil || j.ParentID != "EU" {
+		t.Errorf("expected FR with parent EU, got %+v", j)
+	}
+	if ix.GetJurisdiction("XX") != nil {
+		t.Error("expected nil for unknown jurisdiction")
+	}
+	if m := ix.GetMapping("M2"); m == nil || m.SolutionID != "ovh" {
+		t.Errorf("expected mapping M2 for ovh, got %+v", m)
+	}
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"mappings for REQ-001", len(ix.GetMappingsForRequirement("REQ-001")), 2},
+		{"mappings for aws", len(ix.GetMappingsForSolution("aws")), 2},
+		{"mappings for FR", len(ix.GetMappingsForJurisdiction("FR")), 2},
+		{"mappings for EU", len(ix.GetMappingsForJurisdiction("EU")), 1},
+		{"requirements for EU-GDPR", len(ix.GetRequirementsByRegulation("EU-GDPR")), 2},
+		{"requirements in encryption", len(ix.GetRequirementsByCategory("encryption")), 2},
+		{"high requirements", len(ix.GetRequirementsBySeverity(SeverityHigh)), 2},
+		{"zones for aws", len(ix.GetZoneAssignmentsForSolution("aws")), 1},
+		{"zones for FR", len(ix.GetZoneAssignmentsForJurisdiction("FR")), 2},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestFrameworkIndexDetectsSliceChanges(t *testing.T) {
+	cf := indexTestFramework()
+	ix := NewFrameworkIndex(cf)
+
+	cf.Mappings = append(cf.Mappings, RequirementMapping{ID: "M4", RequirementID: "REQ-003", SolutionID: "aws"})
+	if got := len(ix.GetMappingsForSolution("aws")); got != 3 {
+		t.Errorf("expected 3 aws mappings after append, got %d", got)
+	}
+
+	cf.Mappings = cf.Mappings[:1]
+	if got := len(ix.GetMappingsForSolution("aws")); got != 1 {
+		t.Errorf("expected 1 aws mapping after truncate, got %d", got)
+	}
+	if ix.GetMapping("M2") != nil {
+		t.Error("expected M2 to be gone after truncate")
+	}
+}
+
+func TestFrameworkIndexUpsertAndRemove(t *testing.T) {
+	cf := indexTestFramework()
+	ix := NewFrameworkIndex(cf)
+
+	// Re-key an existing mapping in place.
+	m := *ix.GetMapping("M1")
+	m.SolutionID = "ovh"
+	ix.UpsertMapping(m)
+	if got := len(ix.GetMappingsForSolution("ovh")); got != 2 {
+		t.Errorf("expected 2 ovh mappings after upsert, got %d", got)
+	}
+	if cf.Mappings[0].SolutionID != "ovh" {
+		t.Error("expected upsert to update the underlying framework")
+	}
+
+	ix.UpsertRequirement(Requirement{ID: "REQ-004", Category: "encryption"})
+	if got := len(ix.GetRequirementsByCategory("encryption")); got != 3 {
+		t.Errorf("expected 3 encryption requirements, got %d", got)
+	}
+
+	if !ix.RemoveZoneAssignment("Z1") {
+		t.Error("expected Z1 to be removed")
+	}
+	if ix.RemoveZoneAssignment("Z1") {
+		t.Error("expected second removal of Z1 to report false")
+	}
+	if got := len(ix.GetZoneAssignmentsForJurisdiction("FR")); got != 1 {
+		t.Errorf("expected 1 FR zone assignment after removal, got %d", got)
+	}
+}
+
+func TestFrameworkIndexMatchesLinearScan(t *testing.T) {
+	cf := &ComplianceFramework{}
+	for i := 0; i < 200; i++ {
+		cf.Mappings = append(cf.Mappings, RequirementMapping{
+			ID:            fmt.Sprintf("M%d", i),
+			RequirementID: fmt.Sprintf("REQ-%d", i%7),
+			SolutionID:    fmt.Sprintf("sol-%d", i%5),
+		})
+	}
+	ix := NewFrameworkIndex(cf)
+
+	for i := 0; i < 7; i++ {
+		id := fmt.Sprintf("REQ-%d", i)
+		want := cf.GetMappingsForRequirement(id)
+		got := ix.GetMappingsForRequirement(id)
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d mappings, got %d", id, len(want), len(got))
+		}
+		for j := range want {
+			if got[j].ID != want[j].ID {
+				t.Errorf("%s: expected mapping %s at %d, got %s", id, want[j].ID, j, got[j].ID)
+			}
+		}
+	}
+}
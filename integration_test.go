@@ -108,3 +108,15 @@ func TestQuerySovereignProvider(t *testing.T) {
 		t.Error("expected cloud-provider-b-sovereign to have green zone compliant mappings")
 	}
 }
+
+func TestValidateExampleFramework(t *testing.T) {
+	cf, err := LoadFrameworkFromDir("./examples/minimal")
+	if err != nil {
+		t.Fatalf("Failed to load example framework: %v", err)
+	}
+
+	report := cf.Validate()
+	if !report.Valid() {
+		t.Errorf("expected example framework to validate, got errors: %v", report.Errors())
+	}
+}
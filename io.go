@@ -1,83 +1,301 @@
 package comply
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"strings"
 )
 
 // ReadJSON reads a JSON file and unmarshals it into the provided interface.
 func ReadJSON(path string, v any) error {
+	return (&Loader{}).ReadJSON(path, v)
+}
+
+// ReadJSONFS reads a JSON file from fsys and unmarshals it into the provided interface.
+func ReadJSONFS(fsys fs.FS, name string, v any) error {
+	return (&Loader{}).ReadJSONFS(fsys, name, v)
+}
+
+// Loader reads framework files. The zero value behaves like the package-level
+// loaders; set Mode to report or tolerate invalid enum values.
+type Loader struct {
+	Mode     DecodeMode
+	Warnings []DecodeIssue // invalid values dropped in DecodeLenient mode
+}
+
+// ReadJSON reads a JSON file and unmarshals it into v according to the loader's mode.
+func (l *Loader) ReadJSON(path string, v any) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("reading file %s: %w", path, err)
 	}
+	return l.decode(path, data, v)
+}
+
+// ReadJSONFS reads a JSON file from fsys and unmarshals it into v according to the loader's mode.
+func (l *Loader) ReadJSONFS(fsys fs.FS, name string, v any) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("reading file %s: %w", name, err)
+	}
+	return l.decode(name, data, v)
+}
+
+func (l *Loader) decode(file string, data []byte, v any) error {
+	if l.Mode == DecodeStrict || l.Mode == DecodeLenient {
+		var raw any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("unmarshaling JSON from %s: %w", file, err)
+		}
+		raw, issues := checkEnums(file, reflect.TypeOf(v), raw, l.Mode == DecodeLenient)
+		if len(issues) > 0 {
+			if l.Mode == DecodeStrict {
+				return &DecodeError{Issues: issues}
+			}
+			l.Warnings = append(l.Warnings, issues...)
+			var err error
+			if data, err = json.Marshal(raw); err != nil {
+				return fmt.Errorf("marshaling JSON from %s: %w", file, err)
+			}
+		}
+	}
 	if err := json.Unmarshal(data, v); err != nil {
-		return fmt.Errorf("unmarshaling JSON from %s: %w", path, err)
+		return fmt.Errorf("unmarshaling %s from %s: %w", strings.ToUpper(string(FormatFromPath(file))), file, err)
 	}
 	return nil
 }
 
 // WriteJSON marshals the provided interface and writes it to a JSON file.
+// The file is replaced atomically (see WriteFileAtomic).
 func WriteJSON(path string, v any, indent bool) error {
-	var data []byte
-	var err error
-	if indent {
-		data, err = json.MarshalIndent(v, "", "  ")
-	} else {
-		data, err = json.Marshal(v)
+	data, err := marshalJSON(v, indent)
+	if err != nil {
+		return err
 	}
+	return WriteFileAtomic(path, data)
+}
+
+func marshalJSON(v any, indent bool) ([]byte, error) {
+	data, err := json.Marshal(v)
 	if err != nil {
-		return fmt.Errorf("marshaling JSON: %w", err)
+		return nil, fmt.Errorf("marshaling JSON: %w", err)
 	}
-	data = append(data, '\n')
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("writing file %s: %w", path, err)
+	if indent {
+		data = indentJSON(data)
+	}
+	return append(data, '\n'), nil
+}
+
+// frameworkMetadata is the content of framework.json.
+type frameworkMetadata struct {
+	Name          string     `json:"name"`
+	Version       string     `json:"version"`
+	SchemaVersion int        `json:"schemaVersion,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	LastUpdated   string     `json:"lastUpdated,omitempty" jsonschema:"format=date"`
+	Extensions    Extensions `json:"extensions,omitempty"`
+	unknown       unknownFields
+}
+
+// UnmarshalJSON decodes framework metadata, keeping the fields it does not know.
+func (m *frameworkMetadata) UnmarshalJSON(data []byte) error {
+	type plain frameworkMetadata
+	var err error
+	m.unknown, err = unmarshalKnown(data, (*plain)(m), "frameworkMetadata")
+	return err
+}
+
+// MarshalJSON encodes framework metadata, including the unknown fields it was decoded with.
+func (m frameworkMetadata) MarshalJSON() ([]byte, error) {
+	type plain frameworkMetadata
+	return marshalKnown(plain(m), m.unknown)
+}
+
+// frameworkFile is a collection stored in its own file of a framework directory.
+type frameworkFile struct {
+	base string // file name without extension
+	data any    // pointer to the collection
+}
+
+// frameworkFiles lists the collection files of a framework directory.
+func frameworkFiles(cf *ComplianceFramework) []frameworkFile {
+	return []frameworkFile{
+		{"jurisdictions", &cf.Jurisdictions},
+		{"regulations", &cf.Regulations},
+		{"requirements", &cf.Requirements},
+		{"entities", &cf.RegulatedEntities},
+		{"solutions", &cf.Solutions},
+		{"zone-assignments", &cf.ZoneAssignments},
+		{"mappings", &cf.Mappings},
+		{"enforcement", &cf.EnforcementAssessments},
 	}
-	return nil
 }
 
-// LoadFrameworkFromDir loads a ComplianceFramework from a directory of JSON files.
+// LoadFramework loads a ComplianceFramework from a directory, a .zip archive
+// or a bundle file (see LoadBundle).
+func LoadFramework(path string) (*ComplianceFramework, error) {
+	return (&Loader{}).LoadFramework(path)
+}
+
+// LoadFrameworkFromDir loads a ComplianceFramework from a directory of JSON or YAML files.
 func LoadFrameworkFromDir(dir string) (*ComplianceFramework, error) {
-	cf := &ComplianceFramework{}
+	return (&Loader{}).LoadFrameworkFromDir(dir)
+}
 
-	files := map[string]any{
-		"jurisdictions.json":    &cf.Jurisdictions,
-		"regulations.json":      &cf.Regulations,
-		"requirements.json":     &cf.Requirements,
-		"entities.json":         &cf.RegulatedEntities,
-		"solutions.json":        &cf.Solutions,
-		"zone-assignments.json": &cf.ZoneAssignments,
-		"mappings.json":         &cf.Mappings,
-		"enforcement.json":      &cf.EnforcementAssessments,
-	}
-
-	for filename, dest := range files {
-		path := filepath.Join(dir, filename)
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			continue // File doesn't exist, skip
-		}
-		if err := ReadJSON(path, dest); err != nil {
-			return nil, fmt.Errorf("loading %s: %w", filename, err)
-		}
+// LoadFrameworkFromZip loads a ComplianceFramework from a zip archive (see
+// Loader.LoadFrameworkFromZip).
+func LoadFrameworkFromZip(path string) (*ComplianceFramework, error) {
+	return (&Loader{}).LoadFrameworkFromZip(path)
+}
+
+// LoadFrameworkFS loads a ComplianceFramework from the files at the root of
+// fsys, such as an embed.FS, a zip.Reader or an fstest.MapFS. Use fs.Sub to
+// load from a subdirectory.
+func LoadFrameworkFS(fsys fs.FS) (*ComplianceFramework, error) {
+	return (&Loader{}).LoadFrameworkFS(fsys)
+}
+
+// LoadFramework loads a ComplianceFramework from a directory, a .zip archive
+// or a bundle file.
+func (l *Loader) LoadFramework(path string) (*ComplianceFramework, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return l.LoadFrameworkFromZip(path)
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return l.LoadBundle(path)
+	}
+	return l.LoadFrameworkFromDir(path)
+}
+
+// LoadFrameworkFromDir loads a ComplianceFramework from a directory of JSON
+// or YAML files; each collection may use either format. In DecodeStrict mode
+// every file is checked and the invalid values of all files are returned in
+// a single DecodeError.
+func (l *Loader) LoadFrameworkFromDir(dir string) (*ComplianceFramework, error) {
+	return l.loadFramework(os.DirFS(dir), dir)
+}
+
+// LoadFrameworkFromZip loads a ComplianceFramework from a zip archive. The
+// framework is read from the shallowest directory of the archive that
+// contains framework metadata, or from the archive root.
+func (l *Loader) LoadFrameworkFromZip(path string) (*ComplianceFramework, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive %s: %w", path, err)
 	}
+	defer zr.Close()
 
-	// Try to load framework metadata from framework.json
-	metaPath := filepath.Join(dir, "framework.json")
-	if _, err := os.Stat(metaPath); err == nil {
-		var meta struct {
-			Name        string `json:"name"`
-			Version     string `json:"version"`
-			Description string `json:"description"`
-			LastUpdated string `json:"lastUpdated"`
+	root := frameworkRoot(zr)
+	fsys, err := fs.Sub(zr, root)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s in archive %s: %w", root, path, err)
+	}
+	return l.loadFramework(fsys, filepath.Join(path, filepath.FromSlash(root)))
+}
+
+// LoadFrameworkFS loads a ComplianceFramework from the files at the root of fsys.
+func (l *Loader) LoadFrameworkFS(fsys fs.FS) (*ComplianceFramework, error) {
+	return l.loadFramework(fsys, "")
+}
+
+// frameworkRoot returns the shallowest directory containing framework
+// metadata, or ".".
+func frameworkRoot(fsys fs.FS) string {
+	root, depth := ".", -1
+	_ = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.TrimSuffix(d.Name(), path.Ext(d.Name())) != "framework" {
+			return nil
 		}
-		if err := ReadJSON(metaPath, &meta); err == nil {
+		if n := strings.Count(p, "/"); depth < 0 || n < depth {
+			root, depth = path.Dir(p), n
+		}
+		return nil
+	})
+	return root
+}
+
+// loadFramework loads the framework files from fsys. Files are reported
+// relative to root in errors and decode issues.
+func (l *Loader) loadFramework(fsys fs.FS, root string) (*ComplianceFramework, error) {
+	cf := &ComplianceFramework{}
+
+	// Load framework metadata first: its schema version explains decode errors.
+	if name, err := findFile(fsys, "framework"); err == nil && name != "" {
+		var meta frameworkMetadata
+		data, err := fs.ReadFile(fsys, name)
+		if err == nil && (&Loader{}).decodeFile(name, data, &meta) == nil {
 			cf.Name = meta.Name
 			cf.Version = meta.Version
+			cf.SchemaVersion = meta.SchemaVersion
 			cf.Description = meta.Description
 			cf.LastUpdated = meta.LastUpdated
+			cf.Extensions = meta.Extensions
+			cf.unknown = meta.unknown
+		}
+	}
+	if cf.SchemaVersion > SchemaVersion {
+		return nil, schemaError(cf.SchemaVersion, nil)
+	}
+
+	display := func(name string) string {
+		if root == "" {
+			return name
 		}
+		return filepath.Join(root, name)
+	}
+
+	invalid := &DecodeError{}
+	duplicates := &DuplicateIDError{}
+	for _, f := range frameworkFiles(cf) {
+		name, err := findFile(fsys, f.base)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", f.base, err)
+		}
+		shards, err := shardFiles(fsys, f.base)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", f.base, err)
+		}
+		names := shards
+		if name != "" {
+			names = append([]string{name}, shards...)
+		}
+
+		collection := reflect.ValueOf(f.data).Elem()
+		ids := newIDFiles(f.base)
+		for _, name := range names {
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, fmt.Errorf("loading %s: reading file %s: %w", name, display(name), err)
+			}
+			shard := reflect.New(collection.Type())
+			if err := l.decodeFile(display(name), data, shard.Interface()); err != nil {
+				var de *DecodeError
+				if errors.As(err, &de) {
+					invalid.Issues = append(invalid.Issues, de.Issues...)
+					continue
+				}
+				return nil, schemaError(cf.SchemaVersion, fmt.Errorf("loading %s: %w", name, err))
+			}
+			ids.add(display(name), shard.Elem().Interface())
+			collection.Set(reflect.AppendSlice(collection, shard.Elem()))
+		}
+		duplicates.Duplicates = append(duplicates.Duplicates, ids.duplicates()...)
+	}
+	if len(invalid.Issues) > 0 {
+		return nil, schemaError(cf.SchemaVersion, invalid)
+	}
+	if len(duplicates.Duplicates) > 0 {
+		return nil, duplicates
 	}
 
 	return cf, nil
@@ -85,45 +303,154 @@ func LoadFrameworkFromDir(dir string) (*ComplianceFramework, error) {
 
 // SaveFrameworkToDir saves a ComplianceFramework to a directory of JSON files.
 func SaveFrameworkToDir(cf *ComplianceFramework, dir string) error {
+	return (&Saver{}).SaveFrameworkToDir(cf, dir)
+}
+
+// SaveFrameworkToZip saves a ComplianceFramework to a zip archive of JSON files.
+func SaveFrameworkToZip(cf *ComplianceFramework, path string) error {
+	return (&Saver{}).SaveFrameworkToZip(cf, path)
+}
+
+// SaveFramework writes a ComplianceFramework's JSON files to w, in the same
+// layout LoadFrameworkFS reads.
+func SaveFramework(cf *ComplianceFramework, w FileWriter) error {
+	return (&Saver{}).SaveFramework(cf, w)
+}
+
+// Saver writes framework files. The zero value behaves like the package-level
+// save functions and writes JSON.
+type Saver struct {
+	Format Format               // FormatJSON (default) or FormatYAML
+	Shards map[string]ShardFunc // collections to split into <collection>/<shard> files; see Sharding
+	Staged bool                 // SaveFrameworkToDir saves to a staging copy of the directory and swaps it in
+	Backup string               // with Staged, where to keep the previous directory; any previous backup is replaced
+}
+
+func (s *Saver) format() Format {
+	if s.Format == "" {
+		return FormatJSON
+	}
+	return s.Format
+}
+
+// SaveFrameworkToDir saves a ComplianceFramework to a directory. Framework
+// files the save did not write, such as files of a collection in the other
+// format or shards of a collection that is no longer sharded, are removed so
+// the directory loads back to the same framework.
+//
+// Each file is replaced atomically. With Staged set, the whole save is: the
+// directory is copied to a sibling staging directory, saved there and swapped
+// in with two renames, so a failed save leaves the directory untouched.
+func (s *Saver) SaveFrameworkToDir(cf *ComplianceFramework, dir string) error {
+	if s.Staged {
+		return s.saveStaged(cf, dir)
+	}
+	return s.saveFiles(cf, dir)
+}
+
+// saveFiles saves the framework files into dir in place.
+func (s *Saver) saveFiles(cf *ComplianceFramework, dir string) error {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating directory %s: %w", dir, err)
 	}
+	w := &recordingWriter{FileWriter: DirWriter(dir), written: make(map[string]bool)}
+	if err := s.SaveFramework(cf, w); err != nil {
+		return err
+	}
+
+	fsys := os.DirFS(dir)
+	existing, err := frameworkFileNames(fsys)
+	if err != nil {
+		return err
+	}
+	for _, name := range existing {
+		if w.written[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing %s: %w", name, err)
+		}
+		if sub := path.Dir(name); sub != "." {
+			_ = os.Remove(filepath.Join(dir, filepath.FromSlash(sub))) // only succeeds when empty
+		}
+	}
+	return nil
+}
 
-	files := map[string]any{
-		"jurisdictions.json":    cf.Jurisdictions,
-		"regulations.json":      cf.Regulations,
-		"requirements.json":     cf.Requirements,
-		"entities.json":         cf.RegulatedEntities,
-		"solutions.json":        cf.Solutions,
-		"zone-assignments.json": cf.ZoneAssignments,
-		"mappings.json":         cf.Mappings,
-		"enforcement.json":      cf.EnforcementAssessments,
+// SaveFrameworkToZip saves a ComplianceFramework to a zip archive. The
+// archive is replaced atomically (see WriteFileAtomic).
+func (s *Saver) SaveFrameworkToZip(cf *ComplianceFramework, path string) error {
+	f, err := createAtomic(path)
+	if err != nil {
+		return err
 	}
+	zw := NewZipWriter(f)
+	if err := s.SaveFramework(cf, zw); err != nil {
+		f.abort()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		f.abort()
+		return fmt.Errorf("writing archive %s: %w", path, err)
+	}
+	return f.commit()
+}
 
-	for filename, data := range files {
-		path := filepath.Join(dir, filename)
-		if err := WriteJSON(path, data, true); err != nil {
-			return fmt.Errorf("saving %s: %w", filename, err)
+// SaveFramework writes a ComplianceFramework's files to w, in the same layout
+// LoadFrameworkFS reads. The framework is written in canonical form (see
+// ComplianceFramework.Canonical), so saving the same data always produces
+// the same bytes. Empty collections are written as []. Sharded collections
+// are written to one file per shard, in sorted order.
+func (s *Saver) SaveFramework(cf *ComplianceFramework, w FileWriter) error {
+	cf = cf.Canonical()
+	for _, f := range frameworkFiles(cf) {
+		fn := s.Shards[f.base]
+		if fn == nil {
+			data := f.data
+			if reflect.ValueOf(data).Elem().Len() == 0 {
+				data = []any{}
+			}
+			if err := s.writeFile(w, f.base, data); err != nil {
+				return err
+			}
+			continue
+		}
+		names, shards := splitShards(f.data, fn)
+		for _, name := range names {
+			if err := s.writeFile(w, path.Join(f.base, name), shards[name]); err != nil {
+				return err
+			}
 		}
 	}
 
 	// Save framework metadata
-	meta := struct {
-		Name        string `json:"name"`
-		Version     string `json:"version"`
-		Description string `json:"description,omitempty"`
-		LastUpdated string `json:"lastUpdated,omitempty"`
-	}{
-		Name:        cf.Name,
-		Version:     cf.Version,
-		Description: cf.Description,
-		LastUpdated: cf.LastUpdated,
-	}
-	metaPath := filepath.Join(dir, "framework.json")
-	if err := WriteJSON(metaPath, meta, true); err != nil {
-		return fmt.Errorf("saving framework.json: %w", err)
+	meta := frameworkMetadata{
+		Name:          cf.Name,
+		Version:       cf.Version,
+		SchemaVersion: cf.SchemaVersion,
+		Description:   cf.Description,
+		LastUpdated:   cf.LastUpdated,
+		Extensions:    cf.Extensions,
+		unknown:       cf.unknown,
 	}
+	return s.writeFile(w, "framework", meta)
+}
 
+// writeFile encodes v into base plus the extension of the saver's format.
+// YAML comments are kept when w can read back the previous file.
+func (s *Saver) writeFile(w FileWriter, base string, v any) error {
+	name := base + s.format().extensions()[0]
+	var existing []byte
+	if r, ok := w.(fileReader); ok && s.format() == FormatYAML {
+		existing, _ = r.ReadFile(name)
+	}
+	data, err := encodeFile(s.format(), v, existing)
+	if err != nil {
+		return fmt.Errorf("saving %s: %w", name, err)
+	}
+	if err := w.WriteFile(name, data); err != nil {
+		return fmt.Errorf("saving %s: %w", name, err)
+	}
 	return nil
 }
 
@@ -207,3 +534,58 @@ func LoadExecutiveOverview(path string) (*ExecutiveOverview, error) {
 	}
 	return &overview, nil
 }
+
+// LoadOrganizationProfile loads an organization profile from a JSON file.
+func LoadOrganizationProfile(path string) (*OrganizationProfile, error) {
+	var profile OrganizationProfile
+	if err := ReadJSON(path, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// LoadZoneRuleSet loads a zone derivation rule set from a JSON file.
+func LoadZoneRuleSet(path string) (*ZoneRuleSet, error) {
+	var rs ZoneRuleSet
+	if err := ReadJSON(path, &rs); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// LoadSovereigntyRegimes loads sovereignty regimes from a JSON file.
+func LoadSovereigntyRegimes(path string) ([]SovereigntyRegime, error) {
+	var regimes []SovereigntyRegime
+	if err := ReadJSON(path, &regimes); err != nil {
+		return nil, err
+	}
+	return regimes, nil
+}
+
+// LoadRecommendationWeights loads ranking weights from a JSON file. Fields
+// that are not set keep their DefaultRecommendationWeights values.
+func LoadRecommendationWeights(path string) (*RecommendationWeights, error) {
+	weights := DefaultRecommendationWeights()
+	if err := ReadJSON(path, weights); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// LoadWorkload loads a workload description from a JSON file.
+func LoadWorkload(path string) (*Workload, error) {
+	var w Workload
+	if err := ReadJSON(path, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// LoadDataChangelog loads a data changelog from a JSON file.
+func LoadDataChangelog(path string) (*DataChangelog, error) {
+	var c DataChangelog
+	if err := ReadJSON(path, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
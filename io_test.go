@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestReadWriteJSON(t *testing.T) {
@@ -133,6 +134,97 @@ func TestSaveFrameworkToDir(t *testing.T) {
 	}
 }
 
+func TestLoadFrameworkFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.json":     {Data: []byte(`{"name": "Test Framework", "version": "1.0.0"}`)},
+		"jurisdictions.json": {Data: []byte(`[{"id": "EU", "name": "European Union", "type": "supranational"}]`)},
+		"mappings.json":      {Data: []byte(`[{"id": "M1", "complianceLevel": "compliant"}]`)},
+	}
+
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	if cf.Name != "Test Framework" || len(cf.Jurisdictions) != 1 || len(cf.Mappings) != 1 {
+		t.Errorf("unexpected framework %+v", cf)
+	}
+}
+
+func TestSaveFrameworkMapFS(t *testing.T) {
+	original := &ComplianceFramework{
+		Name:          "Round Trip",
+		Version:       "2.0.0",
+		Jurisdictions: []Jurisdiction{{ID: "FR", Name: "France", Type: JurisdictionCountry}},
+		Mappings:      []RequirementMapping{{ID: "M1", ComplianceLevel: ComplianceBanned}},
+	}
+
+	w := MapFSWriter{}
+	if err := SaveFramework(original, w); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	if err := fstest.TestFS(w, "jurisdictions.json", "mappings.json"); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFrameworkFS(w)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	if d := Diff(original, loaded); !d.Empty() {
+		t.Errorf("expected round trip without changes, got:\n%s", d.Text())
+	}
+}
+
+func TestLoadFrameworkFromZip(t *testing.T) {
+	original := &ComplianceFramework{
+		Name:      "Zipped",
+		Version:   "1.0.0",
+		Solutions: []Solution{{ID: "ovh", Name: "OVHcloud", Type: SolutionSovereign}},
+	}
+
+	// A release artifact that keeps the framework in a subdirectory.
+	path := filepath.Join(t.TempDir(), "release.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := NewZipWriter(f)
+	if err := zw.WriteFile("README.md", []byte("release notes")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFramework(original, prefixWriter{zw, "release/data/"}); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	loaded, err := LoadFramework(path)
+	if err != nil {
+		t.Fatalf("LoadFramework failed: %v", err)
+	}
+	if loaded.Name != "Zipped" || len(loaded.Solutions) != 1 || loaded.Solutions[0].Name != "OVHcloud" {
+		t.Errorf("unexpected framework %+v", loaded)
+	}
+
+	rootZip := filepath.Join(t.TempDir(), "root.zip")
+	if err := SaveFrameworkToZip(original, rootZip); err != nil {
+		t.Fatalf("SaveFrameworkToZip failed: %v", err)
+	}
+	if loaded, err := LoadFrameworkFromZip(rootZip); err != nil || loaded.Name != "Zipped" {
+		t.Errorf("expected archive root to load, got %+v, %v", loaded, err)
+	}
+}
+
+type prefixWriter struct {
+	w      FileWriter
+	prefix string
+}
+
+func (p prefixWriter) WriteFile(name string, data []byte) error {
+	return p.w.WriteFile(p.prefix+name, data)
+}
+
 func TestLoadJurisdictions(t *testing.T) {
 	tmpDir := t.TempDir()
 	testFile := filepath.Join(tmpDir, "jurisdictions.json")
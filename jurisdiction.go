@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // JurisdictionType defines the type of jurisdiction.
 type JurisdictionType string
 
@@ -9,6 +11,17 @@ const (
 	JurisdictionSupranational JurisdictionType = "supranational"
 )
 
+// JurisdictionTypes lists the valid JurisdictionType values.
+var JurisdictionTypes = registerEnum(JurisdictionCountry, JurisdictionRegion, JurisdictionSupranational)
+
+// Valid reports whether t is a known jurisdiction type.
+func (t JurisdictionType) Valid() bool { return slices.Contains(JurisdictionTypes, t) }
+
+// UnmarshalJSON decodes a jurisdiction type, rejecting unknown values with an *EnumError.
+func (t *JurisdictionType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, t, JurisdictionTypes)
+}
+
 // Jurisdiction represents a legal jurisdiction (country, region, or supranational body).
 type Jurisdiction struct {
 	ID          string           `json:"id"`                    // e.g., "EU", "UK", "KSA", "FR"
@@ -18,4 +31,20 @@ type Jurisdiction struct {
 	ParentID    string           `json:"parentId,omitempty"`    // e.g., "DE" -> "EU"
 	MemberIDs   []string         `json:"memberIds,omitempty"`   // for regions: member country IDs
 	Description string           `json:"description,omitempty"`
+	Extensions  Extensions       `json:"extensions,omitempty"`
+	unknown     unknownFields
+}
+
+// UnmarshalJSON decodes a jurisdiction, keeping the fields it does not know.
+func (j *Jurisdiction) UnmarshalJSON(data []byte) error {
+	type plain Jurisdiction
+	var err error
+	j.unknown, err = unmarshalKnown(data, (*plain)(j), "Jurisdiction")
+	return err
+}
+
+// MarshalJSON encodes a jurisdiction, including the unknown fields it was decoded with.
+func (j Jurisdiction) MarshalJSON() ([]byte, error) {
+	type plain Jurisdiction
+	return marshalKnown(plain(j), j.unknown)
 }
@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // ComplianceLevel defines the level of compliance for a requirement-solution mapping.
 type ComplianceLevel string
 
@@ -11,6 +13,17 @@ const (
 	ComplianceBanned      ComplianceLevel = "banned" // Explicitly prohibited
 )
 
+// ComplianceLevels lists the valid ComplianceLevel values.
+var ComplianceLevels = registerEnum(ComplianceFull, CompliancePartial, ComplianceNone, ComplianceConditional, ComplianceBanned)
+
+// Valid reports whether l is a known compliance level.
+func (l ComplianceLevel) Valid() bool { return slices.Contains(ComplianceLevels, l) }
+
+// UnmarshalJSON decodes a compliance level, rejecting unknown values with an *EnumError.
+func (l *ComplianceLevel) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, l, ComplianceLevels)
+}
+
 // RequirementMapping maps a solution to a requirement with compliance status.
 type RequirementMapping struct {
 	ID              string          `json:"id"`
@@ -23,5 +36,22 @@ type RequirementMapping struct {
 	Evidence        []string        `json:"evidence,omitempty"`
 	Conditions      string          `json:"conditions,omitempty"`      // What's needed for compliance
 	ETA             string          `json:"eta,omitempty"`             // Expected availability date (e.g., "2026", "Q4 2026")
-	AssessmentDate  string          `json:"assessmentDate,omitempty"`
+	AssessmentDate  string          `json:"assessmentDate,omitempty" jsonschema:"format=date"`
+	Confidence      ConfidenceLevel `json:"confidence,omitempty"` // Confidence in the assessment
+	Extensions      Extensions      `json:"extensions,omitempty"`
+	unknown         unknownFields
+}
+
+// UnmarshalJSON decodes a mapping, keeping the fields it does not know.
+func (m *RequirementMapping) UnmarshalJSON(data []byte) error {
+	type plain RequirementMapping
+	var err error
+	m.unknown, err = unmarshalKnown(data, (*plain)(m), "RequirementMapping")
+	return err
+}
+
+// MarshalJSON encodes a mapping, including the unknown fields it was decoded with.
+func (m RequirementMapping) MarshalJSON() ([]byte, error) {
+	type plain RequirementMapping
+	return marshalKnown(plain(m), m.unknown)
 }
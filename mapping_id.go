@@ -0,0 +1,147 @@
+package comply
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MappingIDScheme derives the ID of a new mapping from its requirement,
+// solution and jurisdictions. Schemes must be deterministic and ignore the
+// order of jurisdictionIDs, so that importing the same research twice
+// yields the same IDs.
+type MappingIDScheme func(requirementID, solutionID string, jurisdictionIDs []string) string
+
+// Built-in mapping ID schemes.
+const (
+	MappingIDSchemeReadable = "readable"
+	MappingIDSchemeHash     = "hash"
+)
+
+// mappingIDSchemes is the registry of mapping ID schemes, keyed by name.
+var mappingIDSchemes = map[string]MappingIDScheme{
+	MappingIDSchemeReadable: ReadableMappingID,
+	MappingIDSchemeHash:     HashMappingID,
+}
+
+// RegisterMappingIDScheme makes a mapping ID scheme available by name, for
+// example to the -id-scheme flag of comply import-research. It panics if
+// the name is already registered.
+func RegisterMappingIDScheme(name string, scheme MappingIDScheme) {
+	if _, ok := mappingIDSchemes[name]; ok {
+		panic(fmt.Sprintf("comply: duplicate mapping ID scheme %q", name))
+	}
+	mappingIDSchemes[name] = scheme
+}
+
+// LookupMappingIDScheme returns the mapping ID scheme registered as name.
+func LookupMappingIDScheme(name string) (MappingIDScheme, error) {
+	scheme, ok := mappingIDSchemes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mapping ID scheme %q (want one of %s)", name, strings.Join(MappingIDSchemeNames(), ", "))
+	}
+	return scheme, nil
+}
+
+// MappingIDSchemeNames returns the names of the registered mapping ID
+// schemes, sorted.
+func MappingIDSchemeNames() []string {
+	return slices.Sorted(maps.Keys(mappingIDSchemes))
+}
+
+// ReadableMappingID is the default mapping ID scheme: "MAP-" followed by the
+// requirement, the solution and the sorted jurisdictions, such as
+// "MAP-CTL-LEGAL-001-aws-commercial-DE-FR". Mappings without jurisdictions
+// get "MAP-CTL-LEGAL-001-aws-commercial".
+func ReadableMappingID(requirementID, solutionID string, jurisdictionIDs []string) string {
+	parts := append([]string{"MAP", requirementID, solutionID}, canonicalSet(jurisdictionIDs)...)
+	return strings.Join(parts, "-")
+}
+
+// HashMappingID is a mapping ID scheme that keeps IDs short: "MAP-" followed
+// by the first 12 hex digits of a SHA-256 digest of the requirement, the
+// solution and the sorted jurisdictions.
+func HashMappingID(requirementID, solutionID string, jurisdictionIDs []string) string {
+	sum := sha256.Sum256([]byte(mappingKey(requirementID, solutionID, jurisdictionIDs)))
+	return "MAP-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// mappingKey identifies the cells a mapping covers, as
+// "solution / requirement / DE, FR" with the jurisdictions sorted, or
+// "solution / requirement" without jurisdictions.
+func mappingKey(requirementID, solutionID string, jurisdictionIDs []string) string {
+	key := solutionID + " / " + requirementID
+	if jurs := canonicalSet(jurisdictionIDs); len(jurs) > 0 {
+		key += " / " + strings.Join(jurs, ", ")
+	}
+	return key
+}
+
+// MappingIDCollision is an ID that a scheme derived for mappings covering
+// different cells. The second and later mappings get the ID suffixed with
+// a counter, such as "-2".
+type MappingIDCollision struct {
+	ID   string   `json:"id"`
+	Keys []string `json:"keys"` // "solution / requirement / jurisdictions"
+}
+
+func (c MappingIDCollision) String() string {
+	return fmt.Sprintf("mapping ID %q derived for %s", c.ID, strings.Join(c.Keys, " and "))
+}
+
+// mappingIDs assigns IDs to new mappings with a scheme, keeping them unique
+// among the IDs already taken and recording collisions.
+type mappingIDs struct {
+	scheme     MappingIDScheme
+	taken      map[string]string // ID -> mapping key
+	collisions []MappingIDCollision
+}
+
+func newMappingIDs(scheme MappingIDScheme, existing []RequirementMapping) *mappingIDs {
+	if scheme == nil {
+		scheme = ReadableMappingID
+	}
+	a := &mappingIDs{scheme: scheme, taken: make(map[string]string, len(existing))}
+	for _, m := range existing {
+		if _, ok := a.taken[m.ID]; !ok {
+			a.taken[m.ID] = mappingKey(m.RequirementID, m.SolutionID, m.JurisdictionIDs)
+		}
+	}
+	return a
+}
+
+// assign returns the ID for a new mapping. A second mapping for the same
+// cells, such as from a repeated finding, is suffixed without being
+// reported as a collision.
+func (a *mappingIDs) assign(requirementID, solutionID string, jurisdictionIDs []string) string {
+	id := a.scheme(requirementID, solutionID, jurisdictionIDs)
+	key := mappingKey(requirementID, solutionID, jurisdictionIDs)
+	candidate := id
+	for n := 2; ; n++ {
+		other, ok := a.taken[candidate]
+		if !ok {
+			break
+		}
+		if candidate == id && other != key {
+			a.collide(id, other, key)
+		}
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+	a.taken[candidate] = key
+	return candidate
+}
+
+func (a *mappingIDs) collide(id, keyA, keyB string) {
+	for k, c := range a.collisions {
+		if c.ID == id {
+			if !slices.Contains(c.Keys, keyB) {
+				a.collisions[k].Keys = append(c.Keys, keyB)
+			}
+			return
+		}
+	}
+	a.collisions = append(a.collisions, MappingIDCollision{ID: id, Keys: []string{keyA, keyB}})
+}
@@ -0,0 +1,81 @@
+package comply
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMappingIDSchemes(t *testing.T) {
+	if got := ReadableMappingID("R1", "aws", []string{"FR", "DE", "FR"}); got != "MAP-R1-aws-DE-FR" {
+		t.Errorf("ReadableMappingID = %s", got)
+	}
+	if got := ReadableMappingID("R1", "aws", nil); got != "MAP-R1-aws" {
+		t.Errorf("ReadableMappingID without jurisdictions = %s", got)
+	}
+	a, b := HashMappingID("R1", "aws", []string{"FR", "DE"}), HashMappingID("R1", "aws", []string{"DE", "FR"})
+	if a != b || len(a) != len("MAP-")+12 || !strings.HasPrefix(a, "MAP-") {
+		t.Errorf("expected order-insensitive hash IDs, got %s and %s", a, b)
+	}
+	if a == HashMappingID("R1", "aws", []string{"FR"}) {
+		t.Error("expected different jurisdictions to hash differently")
+	}
+
+	if _, err := LookupMappingIDScheme(MappingIDSchemeHash); err != nil {
+		t.Error(err)
+	}
+	if _, err := LookupMappingIDScheme("sequential"); err == nil || !strings.Contains(err.Error(), "hash, readable") {
+		t.Errorf("expected an error listing the schemes, got %v", err)
+	}
+}
+
+func TestToMappingsIsIdempotent(t *testing.T) {
+	findings := []ResearchFinding{
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, Status: "partial"},
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"IT"}, Status: "banned"},
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"IT"}, Status: "banned"},
+	}
+	ri := &ResearchInput{Findings: findings}
+	var ids []string
+	for _, m := range ri.ToMappings() {
+		ids = append(ids, m.ID)
+	}
+	if got := strings.Join(ids, ","); got != "MAP-R1-aws-DE-FR,MAP-R1-aws-IT,MAP-R1-aws-IT-2" {
+		t.Errorf("unexpected IDs %s", got)
+	}
+	if c := ri.MappingIDCollisions(); len(c) != 0 {
+		t.Errorf("expected repeated findings not to collide, got %+v", c)
+	}
+
+	// Reordered findings and jurisdictions keep their IDs.
+	reordered := &ResearchInput{Findings: []ResearchFinding{findings[1], findings[0]}}
+	reordered.Findings[1].JurisdictionIDs = []string{"DE", "FR"}
+	if m := reordered.ToMappings(); m[0].ID != "MAP-R1-aws-IT" || m[1].ID != "MAP-R1-aws-DE-FR" {
+		t.Errorf("expected stable IDs, got %s and %s", m[0].ID, m[1].ID)
+	}
+
+	// Merging the same research again changes nothing.
+	first := ri.Merge(nil)
+	again := ri.Merge(first.Mappings())
+	if len(again.New) != 0 || len(again.Updated) != 0 || len(again.Verdicts) != 0 {
+		t.Errorf("expected re-import to be a no-op, got %+v", again)
+	}
+}
+
+func TestMappingIDCollisions(t *testing.T) {
+	ri := &ResearchInput{
+		IDScheme: func(requirementID, solutionID string, _ []string) string { return "MAP-" + requirementID },
+		Findings: []ResearchFinding{
+			{ControlID: "R1", SolutionID: "aws", Status: "compliant"},
+			{ControlID: "R1", SolutionID: "gcp", Status: "compliant"},
+			{ControlID: "R1", SolutionID: "azure", Status: "compliant"},
+		},
+	}
+	mappings := ri.ToMappings()
+	if mappings[1].ID != "MAP-R1-2" || mappings[2].ID != "MAP-R1-3" {
+		t.Errorf("expected suffixed IDs, got %+v", mappings)
+	}
+	c := ri.MappingIDCollisions()
+	if len(c) != 1 || strings.Join(c[0].Keys, "; ") != "aws / R1; gcp / R1; azure / R1" {
+		t.Errorf("unexpected collisions %+v", c)
+	}
+}
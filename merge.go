@@ -0,0 +1,189 @@
+package comply
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// MergeResult is the outcome of merging research findings into mappings.
+type MergeResult struct {
+	New       []RequirementMapping `json:"new,omitempty"`
+	Updated   []RequirementMapping `json:"updated,omitempty"` // including narrowed mappings
+	Unchanged []RequirementMapping `json:"unchanged,omitempty"`
+	// Verdicts lists the changes per (solution, requirement, jurisdiction)
+	// cell between the existing and the merged mappings.
+	Verdicts []VerdictChange `json:"verdicts,omitempty"`
+	// IDCollisions lists the IDs the ID scheme derived for new mappings
+	// covering different cells; see ResearchInput.MappingIDCollisions.
+	IDCollisions []MappingIDCollision `json:"idCollisions,omitempty"`
+	// History records every changed cell with the research metadata and
+	// source, for appending to the mapping history.
+	History []HistoryEntry `json:"history,omitempty"`
+	// Renamed lists the narrowed mappings whose IDs were derived from their
+	// jurisdictions and were derived again for the jurisdictions they kept.
+	Renamed []MappingRename `json:"renamed,omitempty"`
+
+	mappings []RequirementMapping
+}
+
+// MappingRename is a change of mapping ID.
+type MappingRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (r MappingRename) String() string {
+	return fmt.Sprintf("%s → %s", r.From, r.To)
+}
+
+// Mappings returns the merged mappings: the existing ones in their order,
+// updated in place, followed by the new ones.
+func (r *MergeResult) Mappings() []RequirementMapping {
+	return r.mappings
+}
+
+// Text renders the merge summary and verdict changes as plain text.
+func (r *MergeResult) Text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Merge Summary:\n")
+	fmt.Fprintf(&sb, "  New mappings:       %d\n", len(r.New))
+	fmt.Fprintf(&sb, "  Updated mappings:   %d\n", len(r.Updated))
+	fmt.Fprintf(&sb, "  Unchanged mappings: %d\n", len(r.Unchanged))
+	if len(r.Verdicts) > 0 {
+		fmt.Fprintf(&sb, "\nVerdict changes (%d):\n", len(r.Verdicts))
+		for _, v := range r.Verdicts {
+			fmt.Fprintf(&sb, "  %s %s\n", changeSymbol(v.Type), v)
+		}
+	}
+	if len(r.Renamed) > 0 {
+		fmt.Fprintf(&sb, "\nRenamed mappings (%d):\n", len(r.Renamed))
+		for _, rn := range r.Renamed {
+			fmt.Fprintf(&sb, "  %s\n", rn)
+		}
+	}
+	if len(r.IDCollisions) > 0 {
+		fmt.Fprintf(&sb, "\nID collisions (%d):\n", len(r.IDCollisions))
+		for _, c := range r.IDCollisions {
+			fmt.Fprintf(&sb, "  %s\n", c)
+		}
+	}
+	return sb.String()
+}
+
+// Merge merges research findings into existing mappings cell by cell, where
+// a cell is a (requirement, solution, jurisdiction) triple. Findings are
+// applied in order:
+//
+//   - A mapping whose jurisdictions all fall within a finding takes the
+//     finding's verdict, notes and evidence.
+//   - A mapping that covers only some of the finding's jurisdictions is
+//     narrowed to the others when the verdicts differ, and the shared
+//     jurisdictions move to a new mapping for the finding. When the
+//     verdicts agree it keeps all its jurisdictions and gains the evidence.
+//   - Jurisdictions of the finding that no mapping covers get a new mapping,
+//     with an ID derived by the IDScheme of ri.
+//
+// A narrowed mapping whose ID is the one the IDScheme derives for its
+// previous jurisdictions gets the ID derived for the jurisdictions it keeps,
+// so that IDs keep describing their content; renamed existing mappings are
+// listed in Renamed. Other IDs, such as hand-assigned ones, are kept.
+//
+// Mappings without jurisdictions apply everywhere; only findings without
+// jurisdictions update them, and jurisdiction-specific findings override
+// them with new mappings, as in EffectiveMappings.
+func (ri *ResearchInput) Merge(existing []RequirementMapping) *MergeResult {
+	merged := slices.Clone(existing)
+	ids := newMappingIDs(ri.IDScheme, existing)
+	var renamed []MappingRename
+
+	for _, f := range ri.Findings {
+		level := f.Level()
+		apply := func(m *RequirementMapping) {
+			m.ComplianceLevel = level
+			m.Zone = f.Zone
+			m.Notes = f.Notes
+			m.Evidence = f.Evidence
+			m.ETA = f.ETA
+			m.AssessmentDate = ri.Metadata.ResearchDate
+			m.Confidence = f.Confidence
+		}
+
+		unscoped := len(f.JurisdictionIDs) == 0
+		rest := dedupe(f.JurisdictionIDs) // jurisdictions not yet placed
+		placed := false
+		for k := range merged {
+			m := &merged[k]
+			if m.RequirementID != f.ControlID || m.SolutionID != f.SolutionID || unscoped != (len(m.JurisdictionIDs) == 0) {
+				continue
+			}
+			if unscoped {
+				apply(m)
+				placed = true
+				continue
+			}
+			shared := slices.DeleteFunc(slices.Clone(m.JurisdictionIDs), func(j string) bool { return !slices.Contains(f.JurisdictionIDs, j) })
+			if len(shared) == 0 {
+				continue
+			}
+			switch {
+			case len(shared) == len(m.JurisdictionIDs):
+				apply(m)
+				rest = slices.DeleteFunc(rest, func(j string) bool { return slices.Contains(shared, j) })
+			case m.ComplianceLevel == level && m.Zone == f.Zone:
+				m.Evidence = dedupe(append(slices.Clone(m.Evidence), f.Evidence...))
+				rest = slices.DeleteFunc(rest, func(j string) bool { return slices.Contains(shared, j) })
+			default:
+				derived := m.ID == ids.scheme(m.RequirementID, m.SolutionID, m.JurisdictionIDs)
+				m.JurisdictionIDs = slices.DeleteFunc(slices.Clone(m.JurisdictionIDs), func(j string) bool { return slices.Contains(shared, j) })
+				if derived {
+					id := ids.assign(m.RequirementID, m.SolutionID, m.JurisdictionIDs)
+					if k < len(existing) {
+						renamed = renameMapping(renamed, m.ID, id)
+					}
+					m.ID = id
+				}
+			}
+		}
+		if (unscoped && placed) || (!unscoped && len(rest) == 0) {
+			continue
+		}
+
+		m := RequirementMapping{
+			ID:              ids.assign(f.ControlID, f.SolutionID, rest),
+			RequirementID:   f.ControlID,
+			SolutionID:      f.SolutionID,
+			JurisdictionIDs: rest,
+		}
+		apply(&m)
+		merged = append(merged, m)
+	}
+
+	result := &MergeResult{mappings: merged, Verdicts: diffVerdicts(existing, merged), IDCollisions: ids.collisions,
+		History: HistoryEntries(existing, merged, ri.Metadata, ri.Source), Renamed: renamed}
+	for k, m := range merged {
+		switch {
+		case k >= len(existing):
+			result.New = append(result.New, m)
+		case reflect.DeepEqual(m, existing[k]):
+			result.Unchanged = append(result.Unchanged, m)
+		default:
+			result.Updated = append(result.Updated, m)
+		}
+	}
+	return result
+}
+
+// renameMapping records a rename from one ID to another, following a mapping
+// renamed again through its earlier rename. The rename is moved to the end.
+func renameMapping(renamed []MappingRename, from, to string) []MappingRename {
+	for k, r := range renamed {
+		if r.To == from {
+			from = r.From
+			renamed = slices.Delete(renamed, k, k+1)
+			break
+		}
+	}
+	return append(renamed, MappingRename{From: from, To: to})
+}
@@ -0,0 +1,150 @@
+package comply
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeSplitsDivergingJurisdictions(t *testing.T) {
+	existing := []RequirementMapping{
+		{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, ComplianceLevel: CompliancePartial, Zone: ZoneYellow},
+		{ID: "M2", RequirementID: "R2", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: CompliancePartial},
+	}
+	ri := &ResearchInput{
+		Metadata: ResearchMetadata{ResearchDate: "2026-10-01"},
+		Findings: []ResearchFinding{{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, Status: "banned", Zone: ZoneRed}},
+	}
+
+	result := ri.Merge(existing)
+	if len(result.New) != 1 || len(result.Updated) != 1 || len(result.Unchanged) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if m := result.Updated[0]; m.ID != "M1" || strings.Join(m.JurisdictionIDs, ",") != "DE" || m.ComplianceLevel != CompliancePartial {
+		t.Errorf("expected M1 narrowed to DE with its verdict, got %+v", m)
+	}
+	if len(result.Renamed) != 0 {
+		t.Errorf("expected a hand-assigned ID to be kept, got %+v", result.Renamed)
+	}
+	if m := result.New[0]; strings.Join(m.JurisdictionIDs, ",") != "FR" || m.ComplianceLevel != ComplianceBanned || m.AssessmentDate != "2026-10-01" {
+		t.Errorf("expected a new FR mapping with the finding's verdict, got %+v", m)
+	}
+	if len(existing[0].JurisdictionIDs) != 2 {
+		t.Error("expected the existing mappings to be left unmodified")
+	}
+
+	var got []string
+	for _, v := range result.Verdicts {
+		got = append(got, v.String())
+	}
+	if strings.Join(got, "\n") != "aws / R1 / FR: partial → banned, zone yellow → red" {
+		t.Errorf("unexpected verdict changes:\n%s", strings.Join(got, "\n"))
+	}
+	if len(result.Mappings()) != 3 || result.Mappings()[0].ID != "M1" {
+		t.Errorf("expected existing mappings first, got %+v", result.Mappings())
+	}
+}
+
+func TestMergeOverlappingSeveralMappings(t *testing.T) {
+	existing := []RequirementMapping{
+		{ID: "M1", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: CompliancePartial},
+		{ID: "M2", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"DE", "IT"}, ComplianceLevel: ComplianceFull},
+		{ID: "M3", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"ES", "PT"}, ComplianceLevel: ComplianceFull},
+		{ID: "M4", RequirementID: "R1", SolutionID: "aws", ComplianceLevel: ComplianceConditional},
+	}
+	ri := &ResearchInput{Findings: []ResearchFinding{
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE", "ES", "NL"}, Status: "compliant", Evidence: []string{"https://new"}},
+	}}
+
+	result := ri.Merge(existing)
+	byID := map[string]RequirementMapping{}
+	for _, m := range result.Mappings() {
+		byID[m.ID] = m
+	}
+	if m := byID["M1"]; m.ComplianceLevel != ComplianceFull || strings.Join(m.JurisdictionIDs, ",") != "FR" {
+		t.Errorf("expected M1 updated in place, got %+v", m)
+	}
+	if m := byID["M2"]; strings.Join(m.JurisdictionIDs, ",") != "DE,IT" || strings.Join(m.Evidence, ",") != "https://new" {
+		t.Errorf("expected M2 kept whole with the new evidence, got %+v", m)
+	}
+	if m := byID["M3"]; strings.Join(m.JurisdictionIDs, ",") != "ES,PT" {
+		t.Errorf("expected M3 kept whole, got %+v", m)
+	}
+	if m := byID["M4"]; m.ComplianceLevel != ComplianceConditional {
+		t.Errorf("expected the unscoped mapping to be left alone, got %+v", m)
+	}
+	if len(result.New) != 1 || strings.Join(result.New[0].JurisdictionIDs, ",") != "NL" {
+		t.Errorf("expected a new mapping for NL only, got %+v", result.New)
+	}
+
+	var got []string
+	for _, v := range result.Verdicts {
+		got = append(got, string(v.Type)+" "+v.String())
+	}
+	want := []string{
+		"modified aws / R1 / FR: partial → compliant",
+		"added aws / R1 / NL: (none) → compliant",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected verdict changes:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestMergeFindingsInOrder(t *testing.T) {
+	existing := []RequirementMapping{{ID: "MAP-R1-aws", RequirementID: "R2", SolutionID: "aws"}}
+	ri := &ResearchInput{Findings: []ResearchFinding{
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE"}, Status: "partial"},
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"DE"}, Status: "banned"},
+		{ControlID: "R1", SolutionID: "aws", Status: "conditional"},
+	}}
+
+	result := ri.Merge(existing)
+	var got []string
+	for _, m := range result.New {
+		got = append(got, m.ID+" "+strings.Join(m.JurisdictionIDs, ",")+" "+string(m.ComplianceLevel))
+	}
+	want := []string{
+		"MAP-R1-aws-FR FR partial",
+		"MAP-R1-aws-DE DE banned",
+		"MAP-R1-aws-2  conditional",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected new mappings:\n%s", strings.Join(got, "\n"))
+	}
+	if c := result.IDCollisions; len(c) != 1 || c[0].String() != `mapping ID "MAP-R1-aws" derived for aws / R2 and aws / R1` {
+		t.Errorf("expected the existing mapping's ID to collide, got %+v", c)
+	}
+
+	newMappings, updated, unchanged := ri.MergeWithMappings(existing)
+	if len(newMappings) != 3 || len(updated) != 0 || len(unchanged) != 1 {
+		t.Errorf("unexpected MergeWithMappings counts %d/%d/%d", len(newMappings), len(updated), len(unchanged))
+	}
+}
+
+func TestMergeRenamesNarrowedDerivedIDs(t *testing.T) {
+	existing := []RequirementMapping{
+		{ID: "MAP-R1-aws-DE-FR-IT", RequirementID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR", "DE", "IT"}, ComplianceLevel: CompliancePartial},
+	}
+	ri := &ResearchInput{Findings: []ResearchFinding{
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, Status: "banned"},
+		{ControlID: "R1", SolutionID: "aws", JurisdictionIDs: []string{"IT"}, Status: "compliant"},
+	}}
+
+	result := ri.Merge(existing)
+	var got []string
+	for _, m := range result.Mappings() {
+		got = append(got, m.ID+" "+strings.Join(m.JurisdictionIDs, ","))
+	}
+	want := []string{"MAP-R1-aws-DE DE", "MAP-R1-aws-FR FR", "MAP-R1-aws-IT IT"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected mappings:\n%s", strings.Join(got, "\n"))
+	}
+	if r := result.Renamed; len(r) != 1 || r[0].String() != "MAP-R1-aws-DE-FR-IT → MAP-R1-aws-DE" {
+		t.Errorf("expected one rename of the narrowed mapping, got %+v", r)
+	}
+
+	// Importing the same research again finds the mappings by their IDs.
+	again := ri.Merge(result.Mappings())
+	if len(again.New) != 0 || len(again.Renamed) != 0 || len(again.Updated) != 0 {
+		t.Errorf("expected a repeated merge to change nothing, got %+v", again)
+	}
+}
@@ -0,0 +1,279 @@
+package comply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SchemaVersion is the version of the framework file shapes this package
+// reads and writes. Saving a framework records it as schemaVersion in the
+// framework metadata; files without one have schema version 0.
+const SchemaVersion = 1
+
+// Migration upgrades the files of a framework from schema version From to
+// From+1.
+type Migration struct {
+	From        int
+	Description string
+	// Apply changes the files in place and returns a line per change.
+	Apply func(rf *RawFramework) ([]string, error)
+}
+
+// migrations is the registry of migrations, keyed by the version they
+// upgrade from.
+var migrations = map[int]Migration{}
+
+func registerMigration(m Migration) {
+	if _, ok := migrations[m.From]; ok {
+		panic(fmt.Sprintf("comply: duplicate migration from schema version %d", m.From))
+	}
+	migrations[m.From] = m
+}
+
+// Migrations returns the registered migrations in the order they apply.
+func Migrations() []Migration {
+	list := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		list = append(list, m)
+	}
+	slices.SortFunc(list, func(a, b Migration) int { return a.From - b.From })
+	return list
+}
+
+// RawFramework holds the files of a framework directory as decoded JSON
+// values (maps, slices, strings, json.Number, bools and nil), so that
+// migrations can change shapes the current Go types do not load.
+type RawFramework struct {
+	Files map[string]any // keyed by file name, e.g. "framework.json" or "mappings/aws.yaml"
+}
+
+// Collection returns the collection a file belongs to, such as "mappings"
+// for "mappings/aws.json", or "framework" for the metadata file.
+func (rf *RawFramework) Collection(name string) string {
+	return collectionName(name)
+}
+
+// collectionName returns the collection a framework file belongs to.
+func collectionName(name string) string {
+	base, _, _ := strings.Cut(name, "/")
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// Names returns the file names, sorted.
+func (rf *RawFramework) Names() []string {
+	names := make([]string, 0, len(rf.Files))
+	for name := range rf.Files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SchemaVersion returns the schemaVersion of the framework metadata, or 0.
+func (rf *RawFramework) SchemaVersion() int {
+	if n, ok := rf.metadata()["schemaVersion"].(json.Number); ok {
+		v, _ := n.Int64()
+		return int(v)
+	}
+	return 0
+}
+
+// metadata returns the content of the framework metadata file, or nil.
+func (rf *RawFramework) metadata() map[string]any {
+	for _, name := range rf.Names() {
+		if meta, ok := rf.Files[name].(map[string]any); ok && !strings.Contains(name, "/") && rf.Collection(name) == "framework" {
+			return meta
+		}
+	}
+	return nil
+}
+
+// LoadRawFramework reads the framework files of fsys without decoding them
+// into Go types.
+func LoadRawFramework(fsys fs.FS) (*RawFramework, error) {
+	names, err := frameworkFileNames(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no framework files found")
+	}
+	rf := &RawFramework{Files: make(map[string]any, len(names))}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		if FormatFromPath(name) == FormatYAML {
+			if data, err = yamlToJSON(data, collectionTypes()[collectionName(name)]); err != nil {
+				return nil, fmt.Errorf("unmarshaling YAML from %s: %w", name, err)
+			}
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("unmarshaling %s: %w", name, err)
+		}
+		rf.Files[name] = v
+	}
+	return rf, nil
+}
+
+// MigrationStep is one applied migration of a MigrationReport.
+type MigrationStep struct {
+	From        int      `json:"from"`
+	To          int      `json:"to"`
+	Description string   `json:"description"`
+	Changes     []string `json:"changes,omitempty"`
+}
+
+// MigrationReport lists what Migrate transformed.
+type MigrationReport struct {
+	FromVersion int             `json:"fromVersion"`
+	ToVersion   int             `json:"toVersion"`
+	Steps       []MigrationStep `json:"steps,omitempty"`
+}
+
+// Text renders the report for terminals.
+func (r *MigrationReport) Text() string {
+	if r.FromVersion == r.ToVersion {
+		return fmt.Sprintf("Already at schema version %d.\n", r.ToVersion)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Migrated schema version %d → %d\n", r.FromVersion, r.ToVersion)
+	for _, s := range r.Steps {
+		fmt.Fprintf(&b, "\n%d → %d: %s\n", s.From, s.To, s.Description)
+		if len(s.Changes) == 0 {
+			b.WriteString("  (no changes)\n")
+		}
+		for _, c := range s.Changes {
+			fmt.Fprintf(&b, "  - %s\n", c)
+		}
+	}
+	return b.String()
+}
+
+// Migrate applies the registered migrations from the framework's schema
+// version up to SchemaVersion and sets the new schemaVersion. It fails for
+// frameworks newer than SchemaVersion.
+func Migrate(rf *RawFramework) (*MigrationReport, error) {
+	version := rf.SchemaVersion()
+	if version > SchemaVersion {
+		return nil, schemaError(version, nil)
+	}
+	report := &MigrationReport{FromVersion: version, ToVersion: SchemaVersion}
+	for v := version; v < SchemaVersion; v++ {
+		m, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration from schema version %d", v)
+		}
+		changes, err := m.Apply(rf)
+		if err != nil {
+			return nil, fmt.Errorf("migrating schema version %d to %d: %w", v, v+1, err)
+		}
+		report.Steps = append(report.Steps, MigrationStep{From: v, To: v + 1, Description: m.Description, Changes: changes})
+	}
+	if meta := rf.metadata(); meta != nil {
+		meta["schemaVersion"] = json.Number(fmt.Sprint(SchemaVersion))
+	}
+	return report, nil
+}
+
+// Framework decodes the migrated files into a ComplianceFramework.
+func (rf *RawFramework) Framework() (*ComplianceFramework, error) {
+	fsys := MapFSWriter{}
+	for name, v := range rf.Files {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling %s: %w", name, err)
+		}
+		fsys[name] = data // JSON is valid YAML too
+	}
+	return LoadFrameworkFS(fsys)
+}
+
+// MigrateFS loads the framework in fsys, migrating it to SchemaVersion.
+func MigrateFS(fsys fs.FS) (*ComplianceFramework, *MigrationReport, error) {
+	rf, err := LoadRawFramework(fsys)
+	if err != nil {
+		return nil, nil, err
+	}
+	report, err := Migrate(rf)
+	if err != nil {
+		return nil, nil, err
+	}
+	cf, err := rf.Framework()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cf, report, nil
+}
+
+// schemaError rejects data newer than SchemaVersion, and points to comply
+// migrate when data of an older version fails to decode.
+func schemaError(version int, err error) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("framework schema version %d is newer than supported version %d; upgrade comply", version, SchemaVersion)
+	}
+	var decodeErr *DecodeError
+	var enumErr *EnumError
+	var typeErr *json.UnmarshalTypeError
+	if version < SchemaVersion && (errors.As(err, &decodeErr) || errors.As(err, &enumErr) || errors.As(err, &typeErr)) {
+		return fmt.Errorf("%w\nframework schema version %d is older than %d; try comply migrate", err, version, SchemaVersion)
+	}
+	return err
+}
+
+// collectionTypes maps collection names to the Go types of their files.
+func collectionTypes() map[string]reflect.Type {
+	types := map[string]reflect.Type{"framework": reflect.TypeOf(frameworkMetadata{})}
+	for _, f := range frameworkFiles(&ComplianceFramework{}) {
+		types[f.base] = reflect.TypeOf(f.data).Elem()
+	}
+	return types
+}
+
+func init() {
+	registerMigration(migrateLegacyEnums)
+}
+
+// migrateLegacyEnums upgrades data written before schema versioning, when
+// enum values were not checked on load. Values such as "Non_Compliant" or
+// "Red" are rewritten to the documented spelling when that is unambiguous.
+var migrateLegacyEnums = Migration{
+	From:        0,
+	Description: "normalize legacy enum spellings",
+	Apply: func(rf *RawFramework) ([]string, error) {
+		types := collectionTypes()
+		var changes []string
+		for _, name := range rf.Names() {
+			t, ok := types[rf.Collection(name)]
+			if !ok {
+				continue
+			}
+			rf.Files[name] = walkEnums(t, rf.Files[name], func(p string, _ reflect.Type, allowed []string, s string) (any, bool) {
+				if slices.Contains(allowed, s) {
+					return s, true
+				}
+				fixed := strings.ToLower(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+					return r == ' ' || r == '_' || r == '-'
+				}), "-"))
+				if slices.Contains(allowed, fixed) {
+					changes = append(changes, fmt.Sprintf("%s: %s: %q → %q", name, p, s, fixed))
+					return fixed, true
+				}
+				return s, true
+			})
+		}
+		slices.SortFunc(changes, naturalCompare)
+		return changes, nil
+	},
+}
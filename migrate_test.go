@@ -0,0 +1,81 @@
+package comply
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrateLegacyEnums(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.json":     {Data: []byte(`{"name": "Legacy", "version": "0.1"}`)},
+		"mappings.json":      {Data: []byte(`[{"id": "M1", "complianceLevel": "Non_Compliant", "zone": "Red", "sprint": 3}]`)},
+		"solutions/a.yaml":   {Data: []byte("- id: aws\n  type: National_Partner\n")},
+		"jurisdictions.json": {Data: []byte(`[{"id": "EU", "type": "Supranational"}]`)},
+	}
+
+	_, err := LoadFrameworkFS(fsys)
+	if err == nil || !strings.Contains(err.Error(), "try comply migrate") {
+		t.Fatalf("expected load error pointing to comply migrate, got %v", err)
+	}
+
+	cf, report, err := MigrateFS(fsys)
+	if err != nil {
+		t.Fatalf("MigrateFS failed: %v", err)
+	}
+	if report.FromVersion != 0 || report.ToVersion != SchemaVersion || len(report.Steps) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	want := []string{
+		`jurisdictions.json: $[0].type: "Supranational" → "supranational"`,
+		`mappings.json: $[0].complianceLevel: "Non_Compliant" → "non-compliant"`,
+		`mappings.json: $[0].zone: "Red" → "red"`,
+		`solutions/a.yaml: $[0].type: "National_Partner" → "national-partner"`,
+	}
+	if got := report.Steps[0].Changes; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+	if cf.SchemaVersion != SchemaVersion || cf.Mappings[0].ComplianceLevel != ComplianceNone || cf.Solutions[0].Type != SolutionNationalPartner {
+		t.Errorf("unexpected migrated framework %+v", cf)
+	}
+
+	out := MapFSWriter{}
+	if err := SaveFramework(cf, out); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	if !strings.Contains(string(out["framework.json"]), `"schemaVersion": 1`) {
+		t.Errorf("expected saved metadata to record the schema version:\n%s", out["framework.json"])
+	}
+	if !strings.Contains(string(out["mappings.json"]), `"sprint": 3`) {
+		t.Errorf("expected unknown fields to survive migration:\n%s", out["mappings.json"])
+	}
+	_, report, err = MigrateFS(out)
+	if err != nil || len(report.Steps) != 0 || !strings.Contains(report.Text(), "Already at schema version") {
+		t.Errorf("expected no migration for current data, got %+v, %v", report, err)
+	}
+}
+
+func TestLoadNewerSchemaVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.json": {Data: []byte(`{"name": "Future", "version": "9", "schemaVersion": 99}`)},
+		"mappings.json":  {Data: []byte(`[{"id": "M1", "verdict": {"level": "compliant"}}]`)},
+	}
+	if _, err := LoadFrameworkFS(fsys); err == nil || !strings.Contains(err.Error(), "newer than supported") {
+		t.Errorf("expected newer schema version error, got %v", err)
+	}
+	if _, _, err := MigrateFS(fsys); err == nil {
+		t.Error("expected MigrateFS to refuse newer data")
+	}
+}
+
+func TestMigrationsRegistry(t *testing.T) {
+	list := Migrations()
+	if len(list) != SchemaVersion {
+		t.Fatalf("expected one migration per schema version, got %d", len(list))
+	}
+	for i, m := range list {
+		if m.From != i || m.Description == "" || m.Apply == nil {
+			t.Errorf("unexpected migration %d: %+v", i, m)
+		}
+	}
+}
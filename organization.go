@@ -1,12 +1,64 @@
 package comply
 
+import "slices"
+
 // RegulatedEntity represents a type of organization subject to regulation.
 type RegulatedEntity struct {
-	ID           string   `json:"id"`           // e.g., "NIS2-ESSENTIAL"
-	Name         string   `json:"name"`         // e.g., "Essential Entity"
-	Description  string   `json:"description"`
-	RegulationID string   `json:"regulationId"`
-	Sectors      []string `json:"sectors,omitempty"`  // e.g., ["energy", "transport", "health"]
-	Criteria     string   `json:"criteria,omitempty"` // Criteria for classification
-	Examples     []string `json:"examples,omitempty"` // Example organizations
+	ID           string             `json:"id"`   // e.g., "NIS2-ESSENTIAL"
+	Name         string             `json:"name"` // e.g., "Essential Entity"
+	Description  string             `json:"description"`
+	RegulationID string             `json:"regulationId"`
+	Sectors      []string           `json:"sectors,omitempty"`  // e.g., ["energy", "transport", "health"]
+	Criteria     string             `json:"criteria,omitempty"` // Criteria for classification
+	Sizes        []OrganizationSize `json:"sizes,omitempty"`    // Organization sizes in scope; empty means any
+	Examples     []string           `json:"examples,omitempty"` // Example organizations
+	Extensions   Extensions         `json:"extensions,omitempty"`
+	unknown      unknownFields
+}
+
+// UnmarshalJSON decodes a regulated entity, keeping the fields it does not know.
+func (e *RegulatedEntity) UnmarshalJSON(data []byte) error {
+	type plain RegulatedEntity
+	var err error
+	e.unknown, err = unmarshalKnown(data, (*plain)(e), "RegulatedEntity")
+	return err
+}
+
+// MarshalJSON encodes a regulated entity, including the unknown fields it was decoded with.
+func (e RegulatedEntity) MarshalJSON() ([]byte, error) {
+	type plain RegulatedEntity
+	return marshalKnown(plain(e), e.unknown)
+}
+
+// OrganizationSize classifies an organization by headcount and turnover.
+type OrganizationSize string
+
+const (
+	SizeMicro  OrganizationSize = "micro"
+	SizeSmall  OrganizationSize = "small"
+	SizeMedium OrganizationSize = "medium"
+	SizeLarge  OrganizationSize = "large"
+)
+
+// OrganizationSizes lists the valid OrganizationSize values.
+var OrganizationSizes = registerEnum(SizeMicro, SizeSmall, SizeMedium, SizeLarge)
+
+// Valid reports whether s is a known organization size.
+func (s OrganizationSize) Valid() bool { return slices.Contains(OrganizationSizes, s) }
+
+// UnmarshalJSON decodes a organization size, rejecting unknown values with an *EnumError.
+func (s *OrganizationSize) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, OrganizationSizes)
+}
+
+// OrganizationProfile describes an organization for applicability analysis.
+type OrganizationProfile struct {
+	Name            string           `json:"name,omitempty"`
+	JurisdictionIDs []string         `json:"jurisdictionIds"`       // where the organization operates
+	Sectors         []string         `json:"sectors,omitempty"`     // e.g., ["energy", "banking"]
+	EntityTypes     []string         `json:"entityTypes,omitempty"` // e.g., ["essential-entity"] or RegulatedEntity IDs
+	DataTypes       []string         `json:"dataTypes,omitempty"`   // e.g., ["personal-data", "health-data"]
+	Size            OrganizationSize `json:"size,omitempty"`
+	Employees       int              `json:"employees,omitempty"`
+	AnnualTurnover  string           `json:"annualTurnover,omitempty"` // informational, e.g., "€80M"
 }
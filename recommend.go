@@ -0,0 +1,263 @@
+package comply
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Workload describes what an architect wants to run, for solution ranking.
+type Workload struct {
+	Name            string   `json:"name,omitempty"`
+	JurisdictionIDs []string `json:"jurisdictionIds"`
+	DataCategory    string   `json:"dataCategory,omitempty"` // e.g., "essential-data", "personal-data", "general"
+	EntityType      string   `json:"entityType,omitempty"`   // e.g., "essential-entity"
+	Sector          string   `json:"sector,omitempty"`
+}
+
+// profile returns the organization profile used to find applicable requirements.
+func (w *Workload) profile(jurisdictionID string) *OrganizationProfile {
+	p := &OrganizationProfile{Name: w.Name, JurisdictionIDs: []string{jurisdictionID}}
+	if w.Sector != "" {
+		p.Sectors = []string{w.Sector}
+	}
+	if w.EntityType != "" {
+		p.EntityTypes = []string{w.EntityType}
+	}
+	if w.DataCategory != "" {
+		p.DataTypes = []string{w.DataCategory}
+	}
+	return p
+}
+
+// RecommendationWeights configures solution ranking. The four component
+// weights are normalized, so only their ratios matter.
+type RecommendationWeights struct {
+	Zone               float64                         `json:"zone"`
+	Compliance         float64                         `json:"compliance"`
+	Evidence           float64                         `json:"evidence"`
+	Confidence         float64                         `json:"confidence"`
+	ZoneScores         map[ComplianceZone]float64      `json:"zoneScores,omitempty"`
+	UnassignedZone     float64                         `json:"unassignedZone"` // score when no zone is assigned
+	LevelScores        map[ComplianceLevel]float64     `json:"levelScores,omitempty"`
+	ConfidenceScores   map[ConfidenceLevel]float64     `json:"confidenceScores,omitempty"`
+	UnratedConfidence  float64                         `json:"unratedConfidence"` // score for mappings without a confidence
+	SeverityWeights    map[RequirementSeverity]float64 `json:"severityWeights,omitempty"`
+	BlockingSeverities []RequirementSeverity           `json:"blockingSeverities,omitempty"`
+	BlockingLevels     []ComplianceLevel               `json:"blockingLevels,omitempty"`
+}
+
+// DefaultRecommendationWeights returns the default ranking configuration.
+func DefaultRecommendationWeights() *RecommendationWeights {
+	return &RecommendationWeights{
+		Zone:       0.35,
+		Compliance: 0.4,
+		Evidence:   0.15,
+		Confidence: 0.1,
+		ZoneScores: map[ComplianceZone]float64{
+			ZoneGreen:  1,
+			ZoneYellow: 0.5,
+			ZoneRed:    0,
+		},
+		UnassignedZone: 0.5,
+		LevelScores: map[ComplianceLevel]float64{
+			ComplianceFull:        1,
+			ComplianceConditional: 0.75,
+			CompliancePartial:     0.5,
+			ComplianceNone:        0,
+			ComplianceBanned:      0,
+		},
+		ConfidenceScores: map[ConfidenceLevel]float64{
+			ConfidenceHigh:   1,
+			ConfidenceMedium: 0.6,
+			ConfidenceLow:    0.3,
+		},
+		UnratedConfidence:  0.5,
+		SeverityWeights:    maps.Clone(DefaultSeverityWeights),
+		BlockingSeverities: []RequirementSeverity{SeverityCritical, SeverityHigh},
+		BlockingLevels:     []ComplianceLevel{ComplianceBanned, ComplianceNone},
+	}
+}
+
+// BlockingGap is a gap that disqualifies a solution in a jurisdiction.
+type BlockingGap struct {
+	JurisdictionID string `json:"jurisdictionId"`
+	GapEntry
+}
+
+// SolutionRecommendation is a ranked solution for a workload. Scores range from 0 to 100.
+type SolutionRecommendation struct {
+	Rank            int            `json:"rank"`
+	SolutionID      string         `json:"solutionId"`
+	Name            string         `json:"name,omitempty"`
+	Score           float64        `json:"score"`
+	ZoneScore       float64        `json:"zoneScore"`
+	ComplianceScore float64        `json:"complianceScore"`
+	EvidenceScore   float64        `json:"evidenceScore"`
+	ConfidenceScore float64        `json:"confidenceScore"`
+	Zone            ComplianceZone `json:"zone,omitempty"` // worst zone across the workload's jurisdictions
+	Blocked         bool           `json:"blocked"`
+	BlockReasons    []string       `json:"blockReasons,omitempty"`
+	BlockingGaps    []BlockingGap  `json:"blockingGaps,omitempty"`
+}
+
+// RecommendationResult ranks every solution for a workload.
+type RecommendationResult struct {
+	Workload        Workload                 `json:"workload"`
+	Recommendations []SolutionRecommendation `json:"recommendations"`
+}
+
+// Recommend ranks every solution for a workload. Each solution is scored per
+// jurisdiction on zone fit, severity-weighted compliance across the applicable
+// requirements, evidence coverage and mapping confidence, and the scores are
+// averaged; zone fit uses the worst zone. A solution is blocked when it is not
+// available in a jurisdiction, is in the red zone, or has a blocking gap.
+// Unblocked solutions rank first. A nil weights uses DefaultRecommendationWeights.
+func (cf *ComplianceFramework) Recommend(w *Workload, weights *RecommendationWeights) *RecommendationResult {
+	if weights == nil {
+		weights = DefaultRecommendationWeights()
+	}
+	h := cf.Hierarchy()
+
+	type jurisdictionScope struct {
+		id           string
+		requirements []*Requirement
+		mappings     map[string]ResolvedMapping // by solution|requirement
+		zones        []ResolvedZoneAssignment
+	}
+	scopes := make([]jurisdictionScope, 0, len(w.JurisdictionIDs))
+	for _, jurID := range w.JurisdictionIDs {
+		scope := jurisdictionScope{id: jurID, mappings: make(map[string]ResolvedMapping)}
+		for _, ra := range cf.Applicability(w.profile(jurID)).Requirements {
+			if r := cf.GetRequirement(ra.RequirementID); r != nil {
+				scope.requirements = append(scope.requirements, r)
+			}
+		}
+		for _, m := range cf.EffectiveMappings(jurID) {
+			scope.mappings[m.SolutionID+"|"+m.RequirementID] = m
+		}
+		scope.zones = cf.EffectiveZoneAssignments(jurID)
+		scopes = append(scopes, scope)
+	}
+
+	result := &RecommendationResult{Workload: *w}
+	for _, sol := range cf.Solutions {
+		rec := SolutionRecommendation{SolutionID: sol.ID, Name: sol.Name}
+		zoneScore := 1.0
+		var compliance, evidence, confidence float64
+
+		for _, scope := range scopes {
+			if _, d := h.nearest(scope.id, sol.JurisdictionIDs); d < 0 {
+				rec.Blocked = true
+				rec.BlockReasons = append(rec.BlockReasons, fmt.Sprintf("not available in %s", scope.id))
+			}
+
+			zone := workloadZone(scope.zones, sol.ID, w)
+			zs := weights.UnassignedZone
+			if zone != nil {
+				zs = weights.ZoneScores[zone.Zone]
+				if rec.Zone == "" || zs < weights.ZoneScores[rec.Zone] {
+					rec.Zone = zone.Zone
+				}
+				if zone.Zone == ZoneRed {
+					rec.Blocked = true
+					rec.BlockReasons = append(rec.BlockReasons, fmt.Sprintf("red zone in %s: %s", scope.id, zone.Rationale))
+				}
+			}
+			zoneScore = min(zoneScore, zs)
+
+			var total, met, withEvidence, sure float64
+			var gaps []GapEntry
+			for _, r := range scope.requirements {
+				weight := severityWeight(weights.SeverityWeights, r.Severity)
+				total += weight
+				m, ok := scope.mappings[sol.ID+"|"+r.ID]
+				if !ok {
+					continue
+				}
+				met += weight * weights.LevelScores[m.ComplianceLevel]
+				if len(m.Evidence) > 0 {
+					withEvidence += weight
+				}
+				if c, ok := weights.ConfidenceScores[m.Confidence]; ok {
+					sure += weight * c
+				} else {
+					sure += weight * weights.UnratedConfidence
+				}
+				if slices.Contains(weights.BlockingLevels, m.ComplianceLevel) && slices.Contains(weights.BlockingSeverities, r.Severity) {
+					gaps = append(gaps, newGapEntry(r, &m))
+				}
+			}
+			if total > 0 {
+				compliance += met / total
+				evidence += withEvidence / total
+				confidence += sure / total
+			}
+
+			sortGaps(gaps)
+			for _, g := range gaps {
+				rec.BlockingGaps = append(rec.BlockingGaps, BlockingGap{JurisdictionID: scope.id, GapEntry: g})
+			}
+		}
+
+		if n := float64(len(scopes)); n > 0 {
+			rec.ZoneScore = zoneScore * 100
+			rec.ComplianceScore = compliance / n * 100
+			rec.EvidenceScore = evidence / n * 100
+			rec.ConfidenceScore = confidence / n * 100
+		}
+		if len(rec.BlockingGaps) > 0 {
+			rec.Blocked = true
+			rec.BlockReasons = append(rec.BlockReasons, fmt.Sprintf("%d blocking gaps", len(rec.BlockingGaps)))
+		}
+		if sum := weights.Zone + weights.Compliance + weights.Evidence + weights.Confidence; sum > 0 {
+			rec.Score = (weights.Zone*rec.ZoneScore + weights.Compliance*rec.ComplianceScore +
+				weights.Evidence*rec.EvidenceScore + weights.Confidence*rec.ConfidenceScore) / sum
+		}
+		result.Recommendations = append(result.Recommendations, rec)
+	}
+
+	slices.SortStableFunc(result.Recommendations, func(a, b SolutionRecommendation) int {
+		if a.Blocked != b.Blocked {
+			if a.Blocked {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(b.Score, a.Score)
+	})
+	for i := range result.Recommendations {
+		result.Recommendations[i].Rank = i + 1
+	}
+	return result
+}
+
+// workloadZone returns the solution's zone for the workload's data category
+// and entity type, falling back to an assignment without an entity type.
+func workloadZone(zones []ResolvedZoneAssignment, solutionID string, w *Workload) *ResolvedZoneAssignment {
+	var fallback *ResolvedZoneAssignment
+	for i := range zones {
+		z := &zones[i]
+		if z.SolutionID != solutionID || z.DataCategory != w.DataCategory {
+			continue
+		}
+		if z.EntityType == w.EntityType {
+			return z
+		}
+		if z.EntityType == "" {
+			fallback = z
+		}
+	}
+	return fallback
+}
+
+func severityWeight(weights map[RequirementSeverity]float64, severity RequirementSeverity) float64 {
+	if weights == nil {
+		weights = DefaultSeverityWeights
+	}
+	if w, ok := weights[severity]; ok {
+		return w
+	}
+	return 1
+}
@@ -0,0 +1,90 @@
+package comply
+
+import (
+	"testing"
+)
+
+func recommendTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+		},
+		Regulations: []Regulation{
+			{ID: "FR-SNC", JurisdictionID: "FR", Status: RegulationEnforceable},
+		},
+		Requirements: []Requirement{
+			{ID: "SNC-01", RegulationID: "FR-SNC", Severity: SeverityCritical},
+			{ID: "SNC-02", RegulationID: "FR-SNC", Severity: SeverityLow},
+		},
+		Solutions: []Solution{
+			{ID: "aws", JurisdictionIDs: []string{"EU"}},
+			{ID: "ovh", JurisdictionIDs: []string{"FR"}},
+			{ID: "bleu", JurisdictionIDs: []string{"FR"}},
+			{ID: "govcloud"},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "SNC-01", SolutionID: "aws", ComplianceLevel: ComplianceBanned},
+			{ID: "M2", RequirementID: "SNC-02", SolutionID: "aws", ComplianceLevel: ComplianceFull},
+			{ID: "M3", RequirementID: "SNC-01", SolutionID: "ovh", ComplianceLevel: ComplianceFull, Evidence: []string{"https://example.com"}, Confidence: ConfidenceHigh},
+			{ID: "M4", RequirementID: "SNC-02", SolutionID: "ovh", ComplianceLevel: ComplianceFull},
+			{ID: "M5", RequirementID: "SNC-01", SolutionID: "bleu", ComplianceLevel: CompliancePartial},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "ovh", JurisdictionID: "FR", DataCategory: "essential-data", Zone: ZoneGreen},
+			{ID: "Z2", SolutionID: "bleu", JurisdictionID: "FR", DataCategory: "essential-data", Zone: ZoneYellow},
+		},
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	cf := recommendTestFramework()
+	result := cf.Recommend(&Workload{JurisdictionIDs: []string{"FR"}, DataCategory: "essential-data"}, nil)
+
+	var order []string
+	for _, r := range result.Recommendations {
+		order = append(order, r.SolutionID)
+	}
+	expected := []string{"ovh", "bleu", "aws", "govcloud"}
+	for i := range expected {
+		if i >= len(order) || order[i] != expected[i] {
+			t.Fatalf("expected ranking %v, got %v", expected, order)
+		}
+	}
+
+	ovh := result.Recommendations[0]
+	if ovh.Rank != 1 || ovh.Blocked || ovh.ZoneScore != 100 || ovh.ComplianceScore != 100 {
+		t.Errorf("unexpected ovh recommendation %+v", ovh)
+	}
+	// SNC-01 weighs 4 of 5: evidence 4/5, confidence (4*1 + 1*0.5)/5.
+	if ovh.EvidenceScore != 80 || ovh.ConfidenceScore != 90 {
+		t.Errorf("expected evidence 80 and confidence 90, got %.1f and %.1f", ovh.EvidenceScore, ovh.ConfidenceScore)
+	}
+
+	aws := result.Recommendations[2]
+	if !aws.Blocked || len(aws.BlockingGaps) != 1 || aws.BlockingGaps[0].RequirementID != "SNC-01" {
+		t.Errorf("expected aws to be blocked by banned SNC-01, got %+v", aws)
+	}
+	if gov := result.Recommendations[3]; !gov.Blocked || len(gov.BlockReasons) == 0 {
+		t.Errorf("expected govcloud to be blocked as unavailable, got %+v", gov)
+	}
+}
+
+func TestRecommendCustomWeights(t *testing.T) {
+	cf := recommendTestFramework()
+	weights := DefaultRecommendationWeights()
+	weights.BlockingLevels = nil
+	weights.Zone, weights.Evidence, weights.Confidence = 0, 0, 0
+
+	result := cf.Recommend(&Workload{JurisdictionIDs: []string{"FR"}, DataCategory: "essential-data"}, weights)
+	for _, r := range result.Recommendations {
+		if r.SolutionID == "aws" {
+			if r.Blocked {
+				t.Error("expected aws not to be blocked without blocking levels")
+			}
+			if r.Score != 20 {
+				t.Errorf("expected compliance-only score of 20 for aws, got %.1f", r.Score)
+			}
+		}
+	}
+}
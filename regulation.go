@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // RegulationStatus defines the current status of a regulation.
 type RegulationStatus string
 
@@ -10,6 +12,17 @@ const (
 	RegulationSuperseded  RegulationStatus = "superseded"
 )
 
+// RegulationStatuses lists the valid RegulationStatus values.
+var RegulationStatuses = registerEnum(RegulationDraft, RegulationAdopted, RegulationEnforceable, RegulationSuperseded)
+
+// Valid reports whether s is a known regulation status.
+func (s RegulationStatus) Valid() bool { return slices.Contains(RegulationStatuses, s) }
+
+// UnmarshalJSON decodes a regulation status, rejecting unknown values with an *EnumError.
+func (s *RegulationStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, RegulationStatuses)
+}
+
 // Regulation represents a compliance regulation or directive.
 type Regulation struct {
 	ID                string            `json:"id"`                          // e.g., "EU-NIS2"
@@ -18,23 +31,55 @@ type Regulation struct {
 	Description       string            `json:"description"`
 	JurisdictionID    string            `json:"jurisdictionId"`
 	Status            RegulationStatus  `json:"status"`
-	AdoptedDate       string            `json:"adoptedDate,omitempty"`
-	EffectiveDate     string            `json:"effectiveDate,omitempty"`
-	EnforcementDate   string            `json:"enforcementDate,omitempty"`
-	OfficialURL       string            `json:"officialUrl,omitempty"`
+	AdoptedDate       string            `json:"adoptedDate,omitempty" jsonschema:"format=date"`
+	EffectiveDate     string            `json:"effectiveDate,omitempty" jsonschema:"format=date"`
+	EnforcementDate   string            `json:"enforcementDate,omitempty" jsonschema:"format=date"`
+	OfficialURL       string            `json:"officialUrl,omitempty" jsonschema:"format=uri"`
 	Sections          []Section         `json:"sections,omitempty"`
 	RegulatedEntities []RegulatedEntity `json:"regulatedEntities,omitempty"`
 	ExternalRefs      []ExternalRef     `json:"externalRefs,omitempty"`
 	Tags              []string          `json:"tags,omitempty"`              // e.g., ["data-sovereignty", "cybersecurity"]
+	Extensions        Extensions        `json:"extensions,omitempty"`
+	unknown           unknownFields
+}
+
+// UnmarshalJSON decodes a regulation, keeping the fields it does not know.
+func (r *Regulation) UnmarshalJSON(data []byte) error {
+	type plain Regulation
+	var err error
+	r.unknown, err = unmarshalKnown(data, (*plain)(r), "Regulation")
+	return err
+}
+
+// MarshalJSON encodes a regulation, including the unknown fields it was decoded with.
+func (r Regulation) MarshalJSON() ([]byte, error) {
+	type plain Regulation
+	return marshalKnown(plain(r), r.unknown)
 }
 
 // Section represents a section or article within a regulation.
 type Section struct {
-	ID             string   `json:"id"`                        // e.g., "NIS2-ART21"
-	RegulationID   string   `json:"regulationId"`
-	Number         string   `json:"number"`                    // e.g., "Article 21"
-	Name           string   `json:"name"`
-	Description    string   `json:"description,omitempty"`
-	ParentID       string   `json:"parentId,omitempty"`
-	RequirementIDs []string `json:"requirementIds,omitempty"`
+	ID             string     `json:"id"` // e.g., "NIS2-ART21"
+	RegulationID   string     `json:"regulationId"`
+	Number         string     `json:"number"` // e.g., "Article 21"
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	ParentID       string     `json:"parentId,omitempty"`
+	RequirementIDs []string   `json:"requirementIds,omitempty"`
+	Extensions     Extensions `json:"extensions,omitempty"`
+	unknown        unknownFields
+}
+
+// UnmarshalJSON decodes a section, keeping the fields it does not know.
+func (s *Section) UnmarshalJSON(data []byte) error {
+	type plain Section
+	var err error
+	s.unknown, err = unmarshalKnown(data, (*plain)(s), "Section")
+	return err
+}
+
+// MarshalJSON encodes a section, including the unknown fields it was decoded with.
+func (s Section) MarshalJSON() ([]byte, error) {
+	type plain Section
+	return marshalKnown(plain(s), s.unknown)
 }
@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // RequirementSeverity defines the severity level of a requirement.
 type RequirementSeverity string
 
@@ -10,6 +12,34 @@ const (
 	SeverityLow      RequirementSeverity = "low"
 )
 
+// RequirementSeverities lists the valid RequirementSeverity values.
+var RequirementSeverities = registerEnum(SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow)
+
+// Valid reports whether s is a known requirement severity.
+func (s RequirementSeverity) Valid() bool { return slices.Contains(RequirementSeverities, s) }
+
+// UnmarshalJSON decodes a requirement severity, rejecting unknown values with an *EnumError.
+func (s *RequirementSeverity) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, s, RequirementSeverities)
+}
+
+// Rank orders severities from most to least severe, starting at 0 for
+// critical. Unknown severities rank last.
+func (s RequirementSeverity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 3
+	default:
+		return 4
+	}
+}
+
 // Requirement represents a specific compliance requirement from a regulation.
 type Requirement struct {
 	ID            string              `json:"id"`                       // e.g., "NIS2-ART21-SEC-01"
@@ -23,14 +53,46 @@ type Requirement struct {
 	Keywords      []string            `json:"keywords,omitempty"`
 	RelatedIDs    []string            `json:"relatedIds,omitempty"`     // related requirement IDs
 	ExternalRefs  []ExternalRef       `json:"externalRefs,omitempty"`
-	EffectiveDate string              `json:"effectiveDate,omitempty"`
+	EffectiveDate string              `json:"effectiveDate,omitempty" jsonschema:"format=date"`
 	Applicability *Applicability      `json:"applicability,omitempty"`
+	Extensions    Extensions          `json:"extensions,omitempty"`
+	unknown       unknownFields
+}
+
+// UnmarshalJSON decodes a requirement, keeping the fields it does not know.
+func (r *Requirement) UnmarshalJSON(data []byte) error {
+	type plain Requirement
+	var err error
+	r.unknown, err = unmarshalKnown(data, (*plain)(r), "Requirement")
+	return err
+}
+
+// MarshalJSON encodes a requirement, including the unknown fields it was decoded with.
+func (r Requirement) MarshalJSON() ([]byte, error) {
+	type plain Requirement
+	return marshalKnown(plain(r), r.unknown)
 }
 
 // Applicability defines when a requirement applies.
 type Applicability struct {
-	EntityTypes []string `json:"entityTypes,omitempty"` // e.g., ["essential-entity", "important-entity"]
-	Sectors     []string `json:"sectors,omitempty"`     // e.g., ["energy", "transport", "banking"]
-	DataTypes   []string `json:"dataTypes,omitempty"`   // e.g., ["personal-data", "essential-data"]
-	Conditions  string   `json:"conditions,omitempty"`  // free-form conditions
+	EntityTypes []string           `json:"entityTypes,omitempty"` // e.g., ["essential-entity", "important-entity"]
+	Sectors     []string           `json:"sectors,omitempty"`     // e.g., ["energy", "transport", "banking"]
+	DataTypes   []string           `json:"dataTypes,omitempty"`   // e.g., ["personal-data", "essential-data"]
+	Sizes       []OrganizationSize `json:"sizes,omitempty"`       // e.g., ["medium", "large"]
+	Conditions  string             `json:"conditions,omitempty"`  // free-form conditions
+	unknown     unknownFields
+}
+
+// UnmarshalJSON decodes an applicability, keeping the fields it does not know.
+func (a *Applicability) UnmarshalJSON(data []byte) error {
+	type plain Applicability
+	var err error
+	a.unknown, err = unmarshalKnown(data, (*plain)(a), "Applicability")
+	return err
+}
+
+// MarshalJSON encodes an applicability, including the unknown fields it was decoded with.
+func (a Applicability) MarshalJSON() ([]byte, error) {
+	type plain Applicability
+	return marshalKnown(plain(a), a.unknown)
 }
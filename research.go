@@ -1,9 +1,9 @@
 package comply
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,6 +13,17 @@ import (
 type ResearchInput struct {
 	Metadata ResearchMetadata  `json:"metadata"`
 	Findings []ResearchFinding `json:"findings"`
+
+	// IDScheme derives the IDs of the mappings made from the findings;
+	// nil means ReadableMappingID.
+	IDScheme MappingIDScheme `json:"-"`
+	// Source is the file the research was loaded from, recorded in the
+	// mapping history.
+	Source string `json:"-"`
+
+	// invalid holds the enum values LoadResearchInput dropped, so that
+	// Validate can report them per finding.
+	invalid []DecodeIssue
 }
 
 // ResearchMetadata contains metadata about the research submission
@@ -31,6 +42,17 @@ const (
 	ConfidenceLow    ConfidenceLevel = "low"
 )
 
+// ConfidenceLevels lists the valid ConfidenceLevel values.
+var ConfidenceLevels = registerEnum(ConfidenceHigh, ConfidenceMedium, ConfidenceLow)
+
+// Valid reports whether c is a known confidence level.
+func (c ConfidenceLevel) Valid() bool { return slices.Contains(ConfidenceLevels, c) }
+
+// UnmarshalJSON decodes a confidence level, rejecting unknown values with an *EnumError.
+func (c *ConfidenceLevel) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, c, ConfidenceLevels)
+}
+
 // ResearchFinding represents a single compliance finding from research
 type ResearchFinding struct {
 	RegulationID    string          `json:"regulationId,omitempty"`
@@ -46,6 +68,24 @@ type ResearchFinding struct {
 	Confidence      ConfidenceLevel `json:"confidence,omitempty"`
 }
 
+// Level converts the finding's status to a ComplianceLevel.
+func (f *ResearchFinding) Level() ComplianceLevel {
+	switch f.Status {
+	case "compliant":
+		return ComplianceFull
+	case "partial":
+		return CompliancePartial
+	case "conditional":
+		return ComplianceConditional
+	case "non-compliant":
+		return ComplianceNone
+	case "banned":
+		return ComplianceBanned
+	default:
+		return ComplianceLevel(f.Status)
+	}
+}
+
 // ResearchAnalysis contains analysis results of research findings
 type ResearchAnalysis struct {
 	TotalFindings     int                       `json:"totalFindings"`
@@ -65,10 +105,11 @@ type ResearchAnalysis struct {
 
 // ValidationError represents a validation error in research data
 type ValidationError struct {
-	Index   int    `json:"index"`
-	Field   string `json:"field"`
-	Value   string `json:"value"`
-	Message string `json:"message"`
+	Index       int      `json:"index"`
+	Field       string   `json:"field"`
+	Value       string   `json:"value"`
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions,omitempty"` // e.g., close requirement IDs for an unknown control
 }
 
 // ValidationResult contains the results of validating research input
@@ -79,18 +120,33 @@ type ValidationResult struct {
 	TotalChecked int               `json:"totalChecked"`
 }
 
-// LoadResearchInput loads a research input file from disk
-func LoadResearchInput(path string) (*ResearchInput, error) {
+// LoadResearchInput loads a research input file from disk. Control IDs and
+// names found in the optional alias files (see ControlAliases) are rewritten
+// to canonical requirement IDs.
+func LoadResearchInput(path string, aliasPaths ...string) (*ResearchInput, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read research file: %w", err)
 	}
 
+	// Decode leniently so that Validate reports invalid zones and
+	// confidence levels per finding instead of failing the whole file.
 	var input ResearchInput
-	if err := json.Unmarshal(data, &input); err != nil {
+	loader := &Loader{Mode: DecodeLenient}
+	if err := loader.decode(path, data, &input); err != nil {
 		return nil, fmt.Errorf("failed to parse research JSON: %w", err)
 	}
+	input.invalid = loader.Warnings
 
+	if len(aliasPaths) > 0 {
+		aliases, err := LoadControlAliases(aliasPaths...)
+		if err != nil {
+			return nil, err
+		}
+		input.ResolveControls(aliases, nil)
+	}
+
+	input.Source = path
 	return &input, nil
 }
 
@@ -194,11 +250,27 @@ func (ri *ResearchInput) Validate(framework *ComplianceFramework) *ValidationRes
 		"non-compliant": {}, "banned": {}, "unknown": {},
 	}
 
-	validZones := map[string]struct{}{
-		"red": {}, "yellow": {}, "green": {},
+	// Enum values dropped when loading, keyed by finding index and field.
+	dropped := make(map[int]map[string]string)
+	for _, issue := range ri.invalid {
+		var i int
+		var field string
+		if n, _ := fmt.Sscanf(issue.Path, "$.findings[%d].%s", &i, &field); n == 2 {
+			if dropped[i] == nil {
+				dropped[i] = make(map[string]string)
+			}
+			dropped[i][field] = issue.Value
+		}
 	}
 
 	for i, f := range ri.Findings {
+		if v, ok := dropped[i]["zone"]; ok {
+			f.Zone = ComplianceZone(v)
+		}
+		if v, ok := dropped[i]["confidence"]; ok {
+			f.Confidence = ConfidenceLevel(v)
+		}
+
 		// Check required fields
 		if f.ControlID == "" {
 			result.Errors = append(result.Errors, ValidationError{
@@ -239,11 +311,16 @@ func (ri *ResearchInput) Validate(framework *ComplianceFramework) *ValidationRes
 
 		// Validate control exists (warning only - may be new control)
 		if _, ok := requirementIDs[f.ControlID]; !ok && f.ControlID != "" {
+			var suggestions []string
+			for _, s := range framework.SuggestRequirements(f.ControlID+" "+f.ControlName, 3) {
+				suggestions = append(suggestions, s.String())
+			}
 			result.Warnings = append(result.Warnings, ValidationError{
-				Index:   i,
-				Field:   "controlId",
-				Value:   f.ControlID,
-				Message: "control not found in requirements (may need to add it)",
+				Index:       i,
+				Field:       "controlId",
+				Value:       f.ControlID,
+				Message:     "control not found in requirements (may need to add it)",
+				Suggestions: suggestions,
 			})
 		}
 
@@ -271,16 +348,25 @@ func (ri *ResearchInput) Validate(framework *ComplianceFramework) *ValidationRes
 		}
 
 		// Validate zone
-		if f.Zone != "" {
-			if _, ok := validZones[string(f.Zone)]; !ok {
-				result.Errors = append(result.Errors, ValidationError{
-					Index:   i,
-					Field:   "zone",
-					Value:   string(f.Zone),
-					Message: "invalid zone value",
-				})
-				result.Valid = false
-			}
+		if f.Zone != "" && !f.Zone.Valid() {
+			result.Errors = append(result.Errors, ValidationError{
+				Index:   i,
+				Field:   "zone",
+				Value:   string(f.Zone),
+				Message: "invalid zone value",
+			})
+			result.Valid = false
+		}
+
+		// Validate confidence
+		if f.Confidence != "" && !f.Confidence.Valid() {
+			result.Errors = append(result.Errors, ValidationError{
+				Index:   i,
+				Field:   "confidence",
+				Value:   string(f.Confidence),
+				Message: "invalid confidence value",
+			})
+			result.Valid = false
 		}
 
 		// Warn about missing evidence
@@ -296,139 +382,52 @@ func (ri *ResearchInput) Validate(framework *ComplianceFramework) *ValidationRes
 	return result
 }
 
-// ToMappings converts research findings to RequirementMapping format
+// ToMappings converts research findings to RequirementMapping format.
+// Mapping IDs are derived with IDScheme, so converting the same findings
+// again yields the same IDs.
 func (ri *ResearchInput) ToMappings() []RequirementMapping {
-	mappings := make([]RequirementMapping, 0, len(ri.Findings))
+	mappings, _ := ri.toMappings()
+	return mappings
+}
 
-	for i, f := range ri.Findings {
-		// Convert status to ComplianceLevel
-		var level ComplianceLevel
-		switch f.Status {
-		case "compliant":
-			level = ComplianceFull
-		case "partial":
-			level = CompliancePartial
-		case "conditional":
-			level = ComplianceConditional
-		case "non-compliant":
-			level = ComplianceNone
-		case "banned":
-			level = ComplianceBanned
-		default:
-			level = ComplianceLevel(f.Status)
-		}
+// MappingIDCollisions reports the IDs that IDScheme derives for findings
+// covering different cells. ToMappings keeps such IDs unique by suffixing
+// a counter.
+func (ri *ResearchInput) MappingIDCollisions() []MappingIDCollision {
+	_, collisions := ri.toMappings()
+	return collisions
+}
+
+func (ri *ResearchInput) toMappings() ([]RequirementMapping, []MappingIDCollision) {
+	mappings := make([]RequirementMapping, 0, len(ri.Findings))
+	ids := newMappingIDs(ri.IDScheme, nil)
 
+	for _, f := range ri.Findings {
 		mapping := RequirementMapping{
-			ID:              fmt.Sprintf("MAP-RESEARCH-%04d", i+1),
+			ID:              ids.assign(f.ControlID, f.SolutionID, f.JurisdictionIDs),
 			RequirementID:   f.ControlID,
 			SolutionID:      f.SolutionID,
 			JurisdictionIDs: f.JurisdictionIDs,
-			ComplianceLevel: level,
+			ComplianceLevel: f.Level(),
 			Zone:            f.Zone,
 			Notes:           f.Notes,
 			Evidence:        f.Evidence,
 			ETA:             f.ETA,
 			AssessmentDate:  ri.Metadata.ResearchDate,
+			Confidence:      f.Confidence,
 		}
 
 		mappings = append(mappings, mapping)
 	}
 
-	return mappings
+	return mappings, ids.collisions
 }
 
 // MergeWithMappings merges research findings with existing mappings
-// Returns new mappings, updated mappings, and unchanged mappings
+// Returns new mappings, updated mappings, and unchanged mappings (see Merge)
 func (ri *ResearchInput) MergeWithMappings(existing []RequirementMapping) (new, updated, unchanged []RequirementMapping) {
-	// Build lookup of existing mappings by requirement+solution+jurisdiction key
-	existingMap := make(map[string]*RequirementMapping)
-	for i := range existing {
-		m := &existing[i]
-		for _, j := range m.JurisdictionIDs {
-			key := fmt.Sprintf("%s|%s|%s", m.RequirementID, m.SolutionID, j)
-			existingMap[key] = m
-		}
-		// Also key without jurisdiction for general mappings
-		if len(m.JurisdictionIDs) == 0 {
-			key := fmt.Sprintf("%s|%s|*", m.RequirementID, m.SolutionID)
-			existingMap[key] = m
-		}
-	}
-
-	seenExisting := make(map[string]bool)
-
-	for _, f := range ri.Findings {
-		var level ComplianceLevel
-		switch f.Status {
-		case "compliant":
-			level = ComplianceFull
-		case "partial":
-			level = CompliancePartial
-		case "conditional":
-			level = ComplianceConditional
-		case "non-compliant":
-			level = ComplianceNone
-		case "banned":
-			level = ComplianceBanned
-		default:
-			level = ComplianceLevel(f.Status)
-		}
-
-		// Check if this finding matches an existing mapping
-		var foundExisting *RequirementMapping
-		for _, j := range f.JurisdictionIDs {
-			key := fmt.Sprintf("%s|%s|%s", f.ControlID, f.SolutionID, j)
-			if m, ok := existingMap[key]; ok {
-				foundExisting = m
-				seenExisting[m.ID] = true
-				break
-			}
-		}
-		// Check wildcard
-		if foundExisting == nil {
-			key := fmt.Sprintf("%s|%s|*", f.ControlID, f.SolutionID)
-			if m, ok := existingMap[key]; ok {
-				foundExisting = m
-				seenExisting[m.ID] = true
-			}
-		}
-
-		if foundExisting != nil {
-			// Update existing mapping
-			updatedMapping := *foundExisting
-			updatedMapping.ComplianceLevel = level
-			updatedMapping.Zone = f.Zone
-			updatedMapping.Notes = f.Notes
-			updatedMapping.Evidence = f.Evidence
-			updatedMapping.ETA = f.ETA
-			updatedMapping.AssessmentDate = ri.Metadata.ResearchDate
-			updated = append(updated, updatedMapping)
-		} else {
-			// New mapping
-			newMapping := RequirementMapping{
-				ID:              fmt.Sprintf("MAP-NEW-%s-%s", f.ControlID, f.SolutionID),
-				RequirementID:   f.ControlID,
-				SolutionID:      f.SolutionID,
-				JurisdictionIDs: f.JurisdictionIDs,
-				ComplianceLevel: level,
-				Zone:            f.Zone,
-				Notes:           f.Notes,
-				Evidence:        f.Evidence,
-				ETA:             f.ETA,
-				AssessmentDate:  ri.Metadata.ResearchDate,
-			}
-			new = append(new, newMapping)
-		}
-	}
-
-	// Find unchanged mappings
-	for i := range existing {
-		if !seenExisting[existing[i].ID] {
-			unchanged = append(unchanged, existing[i])
-		}
-	}
-
-	return new, updated, unchanged
+	result := ri.Merge(existing)
+	return result.New, result.Updated, result.Unchanged
 }
 
 // PrintAnalysis prints a formatted analysis report
@@ -476,6 +475,9 @@ func (a *ResearchAnalysis) PrintReport() string {
 }
 
 // GenerateMappingID generates a unique mapping ID
+//
+// Deprecated: IDs embedding the current date change on every import; use a
+// MappingIDScheme such as ReadableMappingID instead.
 func GenerateMappingID(prefix string, index int) string {
 	timestamp := time.Now().Format("20060102")
 	return fmt.Sprintf("MAP-%s-%s-%04d", prefix, timestamp, index)
@@ -0,0 +1,420 @@
+package comply
+
+//go:generate go run ./cmd/comply schema -o schema
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// schemaBaseURL is the base of the $id of the generated schemas.
+const schemaBaseURL = "https://go-comply.dev/schema/"
+
+// SchemaNames returns the names of the generated JSON Schemas: "framework"
+// for the metadata file, one per collection such as "mappings", and
+// "bundle" for framework bundles.
+func SchemaNames() []string {
+	names := []string{"framework"}
+	for _, f := range frameworkFiles(&ComplianceFramework{}) {
+		names = append(names, f.base)
+	}
+	return append(names, "bundle")
+}
+
+// GenerateSchema returns the JSON Schema of the named file, generated from
+// the Go types. Fields without omitempty are required, enum types list their
+// allowed values, and fields tagged jsonschema:"format=date" or "format=uri"
+// carry that format. Unknown fields are allowed, as loading keeps them.
+func GenerateSchema(name string) ([]byte, error) {
+	schema, err := generateSchema(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	return append(indentJSON(data), '\n'), nil
+}
+
+func generateSchema(name string) (map[string]any, error) {
+	t, err := schemaType(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &schemaBuilder{defs: map[string]any{}}
+	schema := b.schema(t)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = schemaBaseURL + name + ".schema.json"
+	schema["title"] = name
+	schema["description"] = "Generated from the go-comply Go types by comply schema; do not edit."
+	schema["$defs"] = b.defs
+	return schema, nil
+}
+
+// schemaType returns the Go type the named schema is generated from.
+func schemaType(name string) (reflect.Type, error) {
+	switch name {
+	case "framework":
+		return reflect.TypeFor[frameworkMetadata](), nil
+	case "bundle":
+		return reflect.TypeFor[ComplianceFramework](), nil
+	}
+	if t := collectionTypes()[name]; t != nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unknown schema %q (want one of %s)", name, strings.Join(SchemaNames(), ", "))
+}
+
+// schemaBuilder builds schemas, collecting named types in defs.
+type schemaBuilder struct {
+	defs map[string]any
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if allowed, ok := enumValues[t]; ok {
+		if _, ok := b.defs[t.Name()]; !ok {
+			b.defs[t.Name()] = map[string]any{"title": t.Name(), "type": "string", "enum": allowed}
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		name := []rune(t.Name())
+		name[0] = unicode.ToUpper(name[0])
+		ref := map[string]any{"$ref": "#/$defs/" + string(name)}
+		if _, ok := b.defs[string(name)]; ok {
+			return ref
+		}
+		def := map[string]any{"title": string(name), "type": "object"}
+		b.defs[string(name)] = def
+		properties := map[string]any{}
+		var required []string
+		b.fields(t, properties, &required)
+		def["properties"] = properties
+		if len(required) > 0 {
+			def["required"] = required
+		}
+		return ref
+	}
+	return map[string]any{}
+}
+
+// fields adds the JSON fields of a struct, including promoted fields of
+// embedded structs, to properties.
+func (b *schemaBuilder) fields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.fields(ft, properties, required)
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := b.schema(f.Type)
+		for _, opt := range strings.Split(f.Tag.Get("jsonschema"), ",") {
+			if format, ok := strings.CutPrefix(opt, "format="); ok {
+				prop["format"] = format
+			}
+		}
+		properties[name] = prop
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// SchemaIssue is a value that does not match its JSON Schema.
+type SchemaIssue struct {
+	File    string `json:"file"`
+	Pointer string `json:"pointer"` // JSON pointer, e.g., "/3/complianceLevel"
+	Message string `json:"message"`
+}
+
+// String renders the issue as "file: pointer: message".
+func (i SchemaIssue) String() string {
+	if i.Pointer == "" {
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Pointer, i.Message)
+}
+
+// ValidateSchemas checks the framework files at path, a directory, a .zip
+// archive or a bundle file, against the generated JSON Schemas. Unlike
+// loading, it reports every mismatch, including wrong types and missing
+// required fields, with its file and JSON pointer.
+func ValidateSchemas(path string) ([]SchemaIssue, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening archive %s: %w", path, err)
+		}
+		defer zr.Close()
+		root := frameworkRoot(zr)
+		fsys, err := fs.Sub(zr, root)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s in archive %s: %w", root, path, err)
+		}
+		return validateSchemasFS(fsys, filepath.Join(path, filepath.FromSlash(root)))
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading file %s: %w", path, err)
+		}
+		return validateSchemaFile(path, data, "bundle")
+	}
+	return validateSchemasFS(os.DirFS(path), path)
+}
+
+// ValidateSchemasFS checks the framework files at the root of fsys against
+// the generated JSON Schemas.
+func ValidateSchemasFS(fsys fs.FS) ([]SchemaIssue, error) {
+	return validateSchemasFS(fsys, "")
+}
+
+func validateSchemasFS(fsys fs.FS, root string) ([]SchemaIssue, error) {
+	names, err := frameworkFileNames(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var issues []SchemaIssue
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		display := name
+		if root != "" {
+			display = filepath.Join(root, name)
+		}
+		found, err := validateSchemaFile(display, data, collectionName(name))
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+	return issues, nil
+}
+
+// validateSchemaFile checks a JSON or YAML file against the named schema.
+func validateSchemaFile(file string, data []byte, schemaName string) ([]SchemaIssue, error) {
+	schema, err := generateSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+	if FormatFromPath(file) == FormatYAML {
+		t, _ := schemaType(schemaName)
+		if data, err = yamlToJSON(data, t); err != nil {
+			return []SchemaIssue{{File: file, Message: fmt.Sprintf("invalid YAML: %v", err)}}, nil
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []SchemaIssue{{File: file, Message: fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+	sv := &schemaValidator{root: schema, file: file}
+	sv.validate(schema, v, "")
+	return sv.issues, nil
+}
+
+// schemaValidator checks decoded JSON against the subset of JSON Schema
+// that GenerateSchema produces: $ref, type, enum, format, properties,
+// required, additionalProperties and items.
+type schemaValidator struct {
+	root   map[string]any
+	file   string
+	issues []SchemaIssue
+}
+
+func (sv *schemaValidator) report(ptr, format string, args ...any) {
+	sv.issues = append(sv.issues, SchemaIssue{File: sv.file, Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+}
+
+func (sv *schemaValidator) validate(s any, v any, ptr string) {
+	schema, ok := s.(map[string]any)
+	if !ok {
+		if s == false {
+			sv.report(ptr, "value is not allowed")
+		}
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		name, found := strings.CutPrefix(ref, "#/$defs/")
+		def, _ := sv.root["$defs"].(map[string]any)
+		if !found || def[name] == nil {
+			sv.report(ptr, "unresolved schema reference %s", ref)
+			return
+		}
+		sv.validate(def[name], v, ptr)
+	}
+
+	if want, ok := schema["type"].(string); ok && jsonType(v, want) != want {
+		sv.report(ptr, "expected %s, got %s", want, jsonType(v, want))
+		return
+	}
+	if allowed, ok := schema["enum"]; ok {
+		values := reflect.ValueOf(allowed)
+		var list []string
+		match := false
+		for i := 0; i < values.Len(); i++ {
+			e := values.Index(i).Interface()
+			list = append(list, fmt.Sprint(e))
+			match = match || reflect.DeepEqual(e, v)
+		}
+		if !match {
+			title, _ := schema["title"].(string)
+			sv.report(ptr, "invalid %s %s (allowed: %s)", cmp.Or(title, "value"), quoteJSON(v), strings.Join(list, ", "))
+		}
+	}
+	if format, ok := schema["format"].(string); ok {
+		if s, isString := v.(string); isString && !validFormat(format, s) {
+			sv.report(ptr, "%q is not a valid %s", s, format)
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		for _, name := range anyStrings(schema["required"]) {
+			if _, ok := v[name]; !ok {
+				sv.report(ptr, "missing required property %q", name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if ps, ok := props[key]; ok {
+				sv.validate(ps, v[key], ptr+"/"+escapePointer(key))
+			} else if ap, ok := schema["additionalProperties"]; ok {
+				sv.validate(ap, v[key], ptr+"/"+escapePointer(key))
+			}
+		}
+	case []any:
+		if items, ok := schema["items"]; ok {
+			for i, elem := range v {
+				sv.validate(items, elem, fmt.Sprintf("%s/%d", ptr, i))
+			}
+		}
+	}
+}
+
+// jsonType returns the JSON Schema type of a decoded value. Numbers are
+// "integer" when want is integer and they have no fraction.
+func jsonType(v any, want string) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil && want == "integer" {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if want == "integer" && v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// validFormat reports whether s is valid for a JSON Schema format. Unknown
+// formats are not checked.
+func validFormat(format, s string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+	}
+	return true
+}
+
+// escapePointer escapes a key as a JSON pointer reference token.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+func anyStrings(v any) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []any:
+		s := make([]string, 0, len(v))
+		for _, e := range v {
+			if str, ok := e.(string); ok {
+				s = append(s, str)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
+func quoteJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
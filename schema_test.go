@@ -0,0 +1,87 @@
+package comply
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCheckedInSchemasUpToDate(t *testing.T) {
+	for _, name := range SchemaNames() {
+		want, err := GenerateSchema(name)
+		if err != nil {
+			t.Fatalf("GenerateSchema(%s) failed: %v", name, err)
+		}
+		got, err := os.ReadFile(filepath.Join("schema", name+".schema.json"))
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("schema/%s.schema.json is out of date; run go generate", name)
+		}
+	}
+}
+
+func TestValidateSchemasFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.json": {Data: []byte(`{"name": "Test", "version": 1, "lastUpdated": "2025-05-01"}`)},
+		"mappings.json": {Data: []byte(`[
+			{"id": "M1", "requirementId": "R1", "solutionId": "aws", "complianceLevel": "compliant", "a/b": {"x": 1}},
+			{"id": "M2", "requirementId": "R1", "complianceLevel": "complaint", "jurisdictionIds": "EU"}
+		]`)},
+		"solutions/aws.yaml": {Data: []byte("- id: aws\n  name: AWS\n  provider: AWS\n  type: commercial\n  ownershipStructure:\n    euOwnershipPercent: low\n")},
+		"regulations.json":   {Data: []byte(`[{"id": "GDPR", "name": "GDPR", "shortName": "GDPR", "description": "", "jurisdictionId": "EU", "status": "enforceable", "officialUrl": "eur-lex", "effectiveDate": "2018-05-25"}]`)},
+	}
+
+	issues, err := ValidateSchemasFS(fsys)
+	if err != nil {
+		t.Fatalf("ValidateSchemasFS failed: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`regulations.json: /0/officialUrl: "eur-lex" is not a valid uri`,
+		`solutions/aws.yaml: /0/ownershipStructure: missing required property "largestNonEuPercent"`,
+		`solutions/aws.yaml: /0/ownershipStructure: missing required property "subjectToExtraTerritorialLaw"`,
+		`solutions/aws.yaml: /0/ownershipStructure/euOwnershipPercent: expected number, got string`,
+		`mappings.json: /1: missing required property "solutionId"`,
+		`mappings.json: /1/complianceLevel: invalid ComplianceLevel "complaint" (allowed: compliant, partial, non-compliant, conditional, banned)`,
+		`mappings.json: /1/jurisdictionIds: expected array, got string`,
+		`framework.json: /version: expected string, got number`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected issues:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestValidateSchemasExamples(t *testing.T) {
+	for _, path := range []string{"examples/minimal", "web/data"} {
+		issues, err := ValidateSchemas(path)
+		if err != nil {
+			t.Fatalf("ValidateSchemas(%s) failed: %v", path, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("unexpected issue: %s", issue)
+		}
+	}
+
+	cf, err := LoadFrameworkFromDir("examples/minimal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := filepath.Join(t.TempDir(), "framework.yaml")
+	if err := SaveBundle(cf, bundle); err != nil {
+		t.Fatal(err)
+	}
+	if issues, err := ValidateSchemas(bundle); err != nil || len(issues) > 0 {
+		t.Errorf("expected a saved bundle to match its schema, got %v, %v", issues, err)
+	}
+}
+
+func TestEscapePointer(t *testing.T) {
+	if got := escapePointer("a/b~c"); got != "a~1b~0c" {
+		t.Errorf("unexpected pointer token %q", got)
+	}
+}
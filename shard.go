@@ -0,0 +1,183 @@
+package comply
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ShardFunc returns the shard an entity is saved to. It receives a pointer to
+// the entity, e.g., *RequirementMapping.
+type ShardFunc func(entity any) string
+
+// ShardStrategies are the built-in shard functions, keyed by collection file
+// name without extension.
+var ShardStrategies = map[string]ShardFunc{
+	"mappings":         func(e any) string { return e.(*RequirementMapping).SolutionID },
+	"requirements":     func(e any) string { return e.(*Requirement).RegulationID },
+	"zone-assignments": func(e any) string { return e.(*ZoneAssignment).SolutionID },
+}
+
+// Sharding returns the built-in shard functions for the named collections,
+// for use as Saver.Shards.
+func Sharding(collections ...string) (map[string]ShardFunc, error) {
+	shards := make(map[string]ShardFunc, len(collections))
+	for _, c := range collections {
+		fn, ok := ShardStrategies[c]
+		if !ok {
+			return nil, fmt.Errorf("no shard strategy for %q (allowed: %s)", c, strings.Join(shardableCollections(), ", "))
+		}
+		shards[c] = fn
+	}
+	return shards, nil
+}
+
+func shardableCollections() []string {
+	names := make([]string, 0, len(ShardStrategies))
+	for name := range ShardStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ShardedCollections returns the collections of fsys that are stored as a
+// directory of shards, such as mappings/<solution-id>.json.
+func ShardedCollections(fsys fs.FS) []string {
+	var sharded []string
+	for _, f := range frameworkFiles(&ComplianceFramework{}) {
+		if shards, _ := shardFiles(fsys, f.base); len(shards) > 0 {
+			sharded = append(sharded, f.base)
+		}
+	}
+	return sharded
+}
+
+// shardFiles returns the JSON and YAML files in the collection directory
+// base, sorted by name, or nil if there is no such directory.
+func shardFiles(fsys fs.FS, base string) ([]string, error) {
+	if !isDir(fsys, base) {
+		return nil, nil
+	}
+	entries, err := fs.ReadDir(fsys, base)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && isFrameworkFile(e.Name()) {
+			names = append(names, path.Join(base, e.Name()))
+		}
+	}
+	return names, nil
+}
+
+func isDir(fsys fs.FS, name string) bool {
+	info, err := fs.Stat(fsys, name)
+	return err == nil && info.IsDir()
+}
+
+func isFrameworkFile(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return slices.Contains(FormatJSON.extensions(), ext) || slices.Contains(FormatYAML.extensions(), ext)
+}
+
+// shardName turns a shard key into a file name, replacing characters that
+// are not safe in file names.
+func shardName(key string) string {
+	if key == "" {
+		return "unassigned"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '-'
+	}, key)
+}
+
+// splitShards groups the entities of a collection by shard, keeping their
+// order within each shard. The shard names are returned sorted.
+func splitShards(collection any, fn ShardFunc) ([]string, map[string]any) {
+	rv := reflect.ValueOf(collection).Elem()
+	groups := make(map[string]reflect.Value)
+	for i := 0; i < rv.Len(); i++ {
+		name := shardName(fn(rv.Index(i).Addr().Interface()))
+		g, ok := groups[name]
+		if !ok {
+			g = reflect.MakeSlice(rv.Type(), 0, 1)
+		}
+		groups[name] = reflect.Append(g, rv.Index(i))
+	}
+	names := make([]string, 0, len(groups))
+	shards := make(map[string]any, len(groups))
+	for name, g := range groups {
+		names = append(names, name)
+		shards[name] = g.Interface()
+	}
+	sort.Strings(names)
+	return names, shards
+}
+
+// DuplicateID is an ID defined in more than one file of a collection.
+type DuplicateID struct {
+	Collection string   `json:"collection"`
+	ID         string   `json:"id"`
+	Files      []string `json:"files"`
+}
+
+// DuplicateIDError is returned when the shards of a collection define the same ID.
+type DuplicateIDError struct {
+	Duplicates []DuplicateID `json:"duplicates"`
+}
+
+func (e *DuplicateIDError) Error() string {
+	lines := make([]string, len(e.Duplicates))
+	for i, d := range e.Duplicates {
+		lines[i] = fmt.Sprintf("duplicate %s ID %q in %s", d.Collection, d.ID, strings.Join(d.Files, " and "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// idFiles records the files each entity ID of a collection was loaded from.
+type idFiles struct {
+	collection string
+	files      map[string][]string
+	order      []string
+}
+
+func newIDFiles(collection string) *idFiles {
+	return &idFiles{collection: collection, files: make(map[string][]string)}
+}
+
+// add records the IDs of the entities in a collection slice loaded from file.
+func (ids *idFiles) add(file string, collection any) {
+	rv := reflect.ValueOf(collection)
+	for i := 0; i < rv.Len(); i++ {
+		id := rv.Index(i).FieldByName("ID").String()
+		if id == "" {
+			continue
+		}
+		if _, ok := ids.files[id]; !ok {
+			ids.order = append(ids.order, id)
+		}
+		if !slices.Contains(ids.files[id], file) {
+			ids.files[id] = append(ids.files[id], file)
+		}
+	}
+}
+
+// duplicates returns the IDs that were loaded from more than one file.
+func (ids *idFiles) duplicates() []DuplicateID {
+	var dups []DuplicateID
+	for _, id := range ids.order {
+		if files := ids.files[id]; len(files) > 1 {
+			dups = append(dups, DuplicateID{Collection: ids.collection, ID: id, Files: files})
+		}
+	}
+	return dups
+}
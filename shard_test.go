@@ -0,0 +1,114 @@
+package comply
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadShardedFramework(t *testing.T) {
+	fsys := fstest.MapFS{
+		"mappings/aws.json":         {Data: []byte(`[{"id": "M1", "solutionId": "aws"}, {"id": "M2", "solutionId": "aws"}]`)},
+		"mappings/ovh.yaml":         {Data: []byte("- id: M3\n  solutionId: ovh\n")},
+		"mappings/README.md":        {Data: []byte("not a shard")},
+		"requirements.json":         {Data: []byte(`[{"id": "R1", "regulationId": "EU-GDPR"}]`)},
+		"requirements/EU-NIS2.json": {Data: []byte(`[{"id": "R2", "regulationId": "EU-NIS2"}]`)},
+	}
+
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	var ids []string
+	for _, m := range cf.Mappings {
+		ids = append(ids, m.ID)
+	}
+	if !slices.Equal(ids, []string{"M1", "M2", "M3"}) {
+		t.Errorf("expected shards merged in file order, got %v", ids)
+	}
+	if len(cf.Requirements) != 2 {
+		t.Errorf("expected single file and shard to be merged, got %v", cf.Requirements)
+	}
+	if got := ShardedCollections(fsys); !slices.Equal(got, []string{"requirements", "mappings"}) {
+		t.Errorf("unexpected sharded collections %v", got)
+	}
+}
+
+func TestLoadShardedDuplicateIDs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"mappings/aws.json": {Data: []byte(`[{"id": "M1"}, {"id": "M2"}]`)},
+		"mappings/ovh.json": {Data: []byte(`[{"id": "M2"}, {"id": "M3"}]`)},
+	}
+
+	_, err := LoadFrameworkFS(fsys)
+	var dupErr *DuplicateIDError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("expected DuplicateIDError, got %v", err)
+	}
+	if len(dupErr.Duplicates) != 1 {
+		t.Fatalf("expected 1 duplicate, got %v", dupErr.Duplicates)
+	}
+	d := dupErr.Duplicates[0]
+	if d.Collection != "mappings" || d.ID != "M2" || !slices.Equal(d.Files, []string{"mappings/aws.json", "mappings/ovh.json"}) {
+		t.Errorf("unexpected duplicate %+v", d)
+	}
+}
+
+func TestSaveShardedFramework(t *testing.T) {
+	cf := &ComplianceFramework{
+		Name: "Sharded",
+		Requirements: []Requirement{
+			{ID: "R1", RegulationID: "EU-NIS2"},
+			{ID: "R2", RegulationID: "EU-GDPR"},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", SolutionID: "ovh"},
+			{ID: "M2", SolutionID: "aws"},
+			{ID: "M3", SolutionID: ""},
+		},
+	}
+	shards, err := Sharding("mappings", "requirements")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	saver := &Saver{Shards: shards}
+	if err := saver.SaveFrameworkToDir(cf, dir); err != nil {
+		t.Fatalf("SaveFrameworkToDir failed: %v", err)
+	}
+	for _, name := range []string{"mappings/aws.json", "mappings/ovh.json", "mappings/unassigned.json", "requirements/EU-GDPR.json", "requirements/EU-NIS2.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected shard %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mappings.json")); !os.IsNotExist(err) {
+		t.Error("expected no mappings.json in sharded layout")
+	}
+
+	loaded, err := LoadFrameworkFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFromDir failed: %v", err)
+	}
+	if d := Diff(cf, loaded); !d.Empty() {
+		t.Errorf("expected sharded round trip without changes, got:\n%s", d.Text())
+	}
+
+	// Saving unsharded replaces the shards with single files.
+	if err := SaveFrameworkToDir(loaded, dir); err != nil {
+		t.Fatalf("SaveFrameworkToDir failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "mappings")); !os.IsNotExist(err) {
+		t.Error("expected mapping shards to be removed")
+	}
+	if again, err := LoadFrameworkFromDir(dir); err != nil || len(again.Mappings) != 3 {
+		t.Errorf("expected 3 mappings after unsharding, got %v, %v", again, err)
+	}
+
+	if _, err := Sharding("solutions"); err == nil {
+		t.Error("expected error for collection without a shard strategy")
+	}
+}
@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // SolutionType defines the type of cloud solution.
 type SolutionType string
 
@@ -11,6 +13,17 @@ const (
 	SolutionPrivate         SolutionType = "private"
 )
 
+// SolutionTypes lists the valid SolutionType values.
+var SolutionTypes = registerEnum(SolutionCommercial, SolutionGovCloud, SolutionSovereign, SolutionNationalPartner, SolutionPrivate)
+
+// Valid reports whether t is a known solution type.
+func (t SolutionType) Valid() bool { return slices.Contains(SolutionTypes, t) }
+
+// UnmarshalJSON decodes a solution type, rejecting unknown values with an *EnumError.
+func (t *SolutionType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, t, SolutionTypes)
+}
+
 // Solution represents a cloud solution or service offering.
 type Solution struct {
 	ID                 string              `json:"id"`                            // e.g., "aws-commercial"
@@ -23,14 +36,46 @@ type Solution struct {
 	OwnershipStructure *OwnershipStructure `json:"ownershipStructure,omitempty"`
 	JurisdictionIDs    []string            `json:"jurisdictionIds,omitempty"`     // Where available
 	ExternalRefs       []ExternalRef       `json:"externalRefs,omitempty"`
+	Extensions         Extensions          `json:"extensions,omitempty"`
+	unknown            unknownFields
+}
+
+// UnmarshalJSON decodes a solution, keeping the fields it does not know.
+func (s *Solution) UnmarshalJSON(data []byte) error {
+	type plain Solution
+	var err error
+	s.unknown, err = unmarshalKnown(data, (*plain)(s), "Solution")
+	return err
+}
+
+// MarshalJSON encodes a solution, including the unknown fields it was decoded with.
+func (s Solution) MarshalJSON() ([]byte, error) {
+	type plain Solution
+	return marshalKnown(plain(s), s.unknown)
 }
 
 // OwnershipStructure captures ownership details for sovereignty compliance.
-// Supports SecNumCloud 24/39 rule: 24% max non-EU ownership, 39% max single non-EU shareholder.
+// Supports the SecNumCloud 24/39 rule: no single non-EU shareholder above 24%,
+// non-EU shareholders together at most 39% (see RegimeSecNumCloud).
 type OwnershipStructure struct {
 	EUOwnershipPercent           float64 `json:"euOwnershipPercent"`
 	LargestNonEUPercent          float64 `json:"largestNonEuPercent"`
 	SubjectToExtraTerritorialLaw bool    `json:"subjectToExtraTerritorialLaw"` // CLOUD Act, etc.
 	ControllingEntity            string  `json:"controllingEntity,omitempty"`
 	Notes                        string  `json:"notes,omitempty"`
+	unknown                      unknownFields
+}
+
+// UnmarshalJSON decodes an ownership structure, keeping the fields it does not know.
+func (o *OwnershipStructure) UnmarshalJSON(data []byte) error {
+	type plain OwnershipStructure
+	var err error
+	o.unknown, err = unmarshalKnown(data, (*plain)(o), "OwnershipStructure")
+	return err
+}
+
+// MarshalJSON encodes an ownership structure, including the unknown fields it was decoded with.
+func (o OwnershipStructure) MarshalJSON() ([]byte, error) {
+	type plain OwnershipStructure
+	return marshalKnown(plain(o), o.unknown)
 }
@@ -0,0 +1,186 @@
+package comply
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EUMemberStates are the ISO 3166-1 alpha-2 codes of the EU member states.
+var EUMemberStates = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// SovereigntyRegime defines the ownership thresholds and extraterritorial
+// exposure tolerance of a sovereignty scheme. A zero threshold is not checked.
+type SovereigntyRegime struct {
+	ID                              string  `json:"id"`
+	Name                            string  `json:"name"`
+	RegulationID                    string  `json:"regulationId,omitempty"`
+	MaxSingleNonEUPercent           float64 `json:"maxSingleNonEuPercent,omitempty"`     // largest single non-EU shareholder
+	MaxCollectiveNonEUPercent       float64 `json:"maxCollectiveNonEuPercent,omitempty"` // all non-EU shareholders together
+	RequireExtraTerritorialImmunity bool    `json:"requireExtraTerritorialImmunity,omitempty"`
+	Notes                           string  `json:"notes,omitempty"`
+}
+
+// Built-in sovereignty regimes.
+var (
+	// RegimeSecNumCloud is the SecNumCloud 3.2 immunity rule (section 19.6): no
+	// single non-EU shareholder above 24% and non-EU shareholders together at most 39%.
+	RegimeSecNumCloud = SovereigntyRegime{
+		ID:                              "secnumcloud",
+		Name:                            "SecNumCloud 3.2",
+		RegulationID:                    "FR-SECNUMCLOUD-3.2",
+		MaxSingleNonEUPercent:           24,
+		MaxCollectiveNonEUPercent:       39,
+		RequireExtraTerritorialImmunity: true,
+	}
+
+	// RegimeEUCSHighPlus is the draft EUCS High+ level, which requires that the
+	// provider is not effectively controlled from outside the EU.
+	RegimeEUCSHighPlus = SovereigntyRegime{
+		ID:                              "eucs-high-plus",
+		Name:                            "EUCS High+",
+		RegulationID:                    "EU-EUCS",
+		MaxSingleNonEUPercent:           49,
+		MaxCollectiveNonEUPercent:       49,
+		RequireExtraTerritorialImmunity: true,
+		Notes:                           "Draft criteria; thresholds approximate no effective non-EU control",
+	}
+)
+
+// DefaultSovereigntyRegimes returns the built-in regimes.
+func DefaultSovereigntyRegimes() []SovereigntyRegime {
+	return []SovereigntyRegime{RegimeSecNumCloud, RegimeEUCSHighPlus}
+}
+
+// ExposureVerdict classifies a solution's exposure to extraterritorial law.
+type ExposureVerdict string
+
+const (
+	ExposureNone     ExposureVerdict = "none"     // no known exposure
+	ExposureIndirect ExposureVerdict = "indirect" // controlled from outside the EU
+	ExposureDirect   ExposureVerdict = "direct"   // explicitly subject to extraterritorial law (CLOUD Act, etc.)
+	ExposureUnknown  ExposureVerdict = "unknown"  // no ownership data
+)
+
+// ThresholdCheck is the result of checking one ownership threshold. Margin is
+// the headroom below the limit; a negative margin is the amount over the limit.
+type ThresholdCheck struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Limit  float64 `json:"limit"`
+	Margin float64 `json:"margin"`
+	Pass   bool    `json:"pass"`
+}
+
+// Names of the ownership threshold checks.
+const (
+	ThresholdSingleNonEU     = "single non-EU shareholder"
+	ThresholdCollectiveNonEU = "collective non-EU ownership"
+)
+
+// ExtraterritorialExposure explains the exposure verdict.
+type ExtraterritorialExposure struct {
+	Verdict            ExposureVerdict `json:"verdict"`
+	ControllingEntity  string          `json:"controllingEntity,omitempty"`
+	ControllingCountry string          `json:"controllingCountry,omitempty"`
+	Reasons            []string        `json:"reasons,omitempty"`
+}
+
+// SovereigntyEvaluation is the result of evaluating a solution against a regime.
+type SovereigntyEvaluation struct {
+	SolutionID    string                   `json:"solutionId"`
+	RegimeID      string                   `json:"regimeId"`
+	Ownership     []ThresholdCheck         `json:"ownership,omitempty"`
+	OwnershipPass bool                     `json:"ownershipPass"`
+	Exposure      ExtraterritorialExposure `json:"exposure"`
+	Pass          bool                     `json:"pass"`
+}
+
+// OwnershipCheck returns the ownership threshold check with the given name,
+// or nil if the regime does not set that threshold.
+func (ev *SovereigntyEvaluation) OwnershipCheck(name string) *ThresholdCheck {
+	for i := range ev.Ownership {
+		if ev.Ownership[i].Name == name {
+			return &ev.Ownership[i]
+		}
+	}
+	return nil
+}
+
+// Evaluate checks a solution's OwnershipStructure against the regime's
+// thresholds and derives its extraterritorial exposure. Solutions without
+// ownership data fail with an unknown exposure.
+func (r *SovereigntyRegime) Evaluate(sol *Solution) *SovereigntyEvaluation {
+	ev := &SovereigntyEvaluation{SolutionID: sol.ID, RegimeID: r.ID}
+	o := sol.OwnershipStructure
+	if o == nil {
+		ev.Exposure = ExtraterritorialExposure{
+			Verdict: ExposureUnknown,
+			Reasons: []string{"no ownership structure recorded"},
+		}
+		return ev
+	}
+
+	ev.OwnershipPass = true
+	check := func(name string, value, limit float64) {
+		if limit <= 0 {
+			return
+		}
+		tc := ThresholdCheck{Name: name, Value: value, Limit: limit, Margin: limit - value, Pass: value <= limit}
+		ev.Ownership = append(ev.Ownership, tc)
+		ev.OwnershipPass = ev.OwnershipPass && tc.Pass
+	}
+	check(ThresholdSingleNonEU, o.LargestNonEUPercent, r.MaxSingleNonEUPercent)
+	check(ThresholdCollectiveNonEU, 100-o.EUOwnershipPercent, r.MaxCollectiveNonEUPercent)
+
+	ev.Exposure = r.exposure(o)
+	ev.Pass = ev.OwnershipPass
+	if r.RequireExtraTerritorialImmunity && ev.Exposure.Verdict != ExposureNone {
+		ev.Pass = false
+	}
+	return ev
+}
+
+func (r *SovereigntyRegime) exposure(o *OwnershipStructure) ExtraterritorialExposure {
+	ex := ExtraterritorialExposure{
+		Verdict:            ExposureNone,
+		ControllingEntity:  o.ControllingEntity,
+		ControllingCountry: controllingCountry(o.ControllingEntity),
+	}
+	if o.SubjectToExtraTerritorialLaw {
+		ex.Verdict = ExposureDirect
+		ex.Reasons = append(ex.Reasons, "subject to extraterritorial law")
+	}
+	if c := ex.ControllingCountry; c != "" && !slices.Contains(EUMemberStates, c) {
+		if ex.Verdict == ExposureNone {
+			ex.Verdict = ExposureIndirect
+		}
+		ex.Reasons = append(ex.Reasons, fmt.Sprintf("controlled from %s", c))
+	}
+	if ex.ControllingCountry == "" && o.ControllingEntity != "" {
+		ex.Reasons = append(ex.Reasons, "controlling entity country not recorded")
+	}
+	return ex
+}
+
+// controllingCountry extracts the country code from a controlling entity such
+// as "Amazon.com Inc. (US)".
+func controllingCountry(entity string) string {
+	open := strings.LastIndex(entity, "(")
+	if open < 0 || !strings.HasSuffix(entity, ")") {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(entity[open+1 : len(entity)-1]))
+}
+
+// EvaluateSovereignty evaluates every solution against a regime.
+func (cf *ComplianceFramework) EvaluateSovereignty(regime *SovereigntyRegime) []SovereigntyEvaluation {
+	result := make([]SovereigntyEvaluation, 0, len(cf.Solutions))
+	for i := range cf.Solutions {
+		result = append(result, *regime.Evaluate(&cf.Solutions[i]))
+	}
+	return result
+}
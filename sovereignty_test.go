@@ -0,0 +1,66 @@
+package comply
+
+import (
+	"testing"
+)
+
+func TestSecNumCloudOwnershipThresholds(t *testing.T) {
+	sol := &Solution{ID: "jv", OwnershipStructure: &OwnershipStructure{
+		EUOwnershipPercent:  65,
+		LargestNonEUPercent: 30,
+		ControllingEntity:   "Joint Venture SAS (FR)",
+	}}
+
+	ev := RegimeSecNumCloud.Evaluate(sol)
+	if ev.OwnershipPass || ev.Pass {
+		t.Fatalf("expected 30%% single non-EU shareholder to fail SecNumCloud, got %+v", ev)
+	}
+	if len(ev.Ownership) != 2 {
+		t.Fatalf("expected 2 threshold checks, got %d", len(ev.Ownership))
+	}
+	if single := ev.Ownership[0]; single.Pass || single.Margin != -6 {
+		t.Errorf("expected single shareholder check to fail by 6 points, got %+v", single)
+	}
+	if collective := ev.Ownership[1]; !collective.Pass || collective.Margin != 4 {
+		t.Errorf("expected collective check to pass with 4 points margin, got %+v", collective)
+	}
+	if ev.Exposure.Verdict != ExposureNone || ev.Exposure.ControllingCountry != "FR" {
+		t.Errorf("expected no exposure for FR-controlled entity, got %+v", ev.Exposure)
+	}
+
+	// The same structure passes the looser EUCS High+ thresholds.
+	if ev := RegimeEUCSHighPlus.Evaluate(sol); !ev.Pass {
+		t.Errorf("expected EUCS High+ to pass, got %+v", ev)
+	}
+	// A regime with only a collective limit has no single-shareholder check.
+	collectiveOnly := &SovereigntyRegime{ID: "collective", MaxCollectiveNonEUPercent: 49}
+	ev = collectiveOnly.Evaluate(sol)
+	if ev.OwnershipCheck(ThresholdSingleNonEU) != nil {
+		t.Error("expected no single shareholder check")
+	}
+	if c := ev.OwnershipCheck(ThresholdCollectiveNonEU); c == nil || c.Margin != 14 {
+		t.Errorf("expected the collective check with 14 points margin, got %+v", c)
+	}
+}
+
+func TestExtraterritorialExposure(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownership *OwnershipStructure
+		verdict   ExposureVerdict
+	}{
+		{"direct", &OwnershipStructure{LargestNonEUPercent: 100, SubjectToExtraTerritorialLaw: true, ControllingEntity: "Amazon.com Inc. (US)"}, ExposureDirect},
+		{"indirect", &OwnershipStructure{EUOwnershipPercent: 100, ControllingEntity: "Holding Ltd (ch)"}, ExposureIndirect},
+		{"none", &OwnershipStructure{EUOwnershipPercent: 100, ControllingEntity: "OVH Groupe SAS (FR)"}, ExposureNone},
+		{"unknown", nil, ExposureUnknown},
+	}
+	for _, tt := range tests {
+		ev := RegimeSecNumCloud.Evaluate(&Solution{ID: tt.name, OwnershipStructure: tt.ownership})
+		if ev.Exposure.Verdict != tt.verdict {
+			t.Errorf("%s: expected %s exposure, got %s", tt.name, tt.verdict, ev.Exposure.Verdict)
+		}
+		if tt.verdict != ExposureNone && ev.Pass {
+			t.Errorf("%s: expected regime requiring immunity to fail", tt.name)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package comply
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// DiagnosticSeverity defines how serious a validation diagnostic is.
+type DiagnosticSeverity string
+
+const (
+	DiagnosticError   DiagnosticSeverity = "error"
+	DiagnosticWarning DiagnosticSeverity = "warning"
+)
+
+// EntityKind identifies the collection an entity belongs to.
+type EntityKind string
+
+const (
+	KindJurisdiction          EntityKind = "jurisdiction"
+	KindRegulation            EntityKind = "regulation"
+	KindSection               EntityKind = "section"
+	KindRequirement           EntityKind = "requirement"
+	KindRegulatedEntity       EntityKind = "regulatedEntity"
+	KindSolution              EntityKind = "solution"
+	KindZoneAssignment        EntityKind = "zoneAssignment"
+	KindMapping               EntityKind = "mapping"
+	KindEnforcementAssessment EntityKind = "enforcementAssessment"
+)
+
+// RuleCode identifies the validation rule that produced a diagnostic.
+type RuleCode string
+
+const (
+	RuleMissingID            RuleCode = "missing-id"
+	RuleDuplicateID          RuleCode = "duplicate-id"
+	RuleUnknownJurisdiction  RuleCode = "unknown-jurisdiction"
+	RuleUnknownRegulation    RuleCode = "unknown-regulation"
+	RuleUnknownSection       RuleCode = "unknown-section"
+	RuleUnknownRequirement   RuleCode = "unknown-requirement"
+	RuleUnknownSolution      RuleCode = "unknown-solution"
+	RuleSelfReference        RuleCode = "self-reference"
+	RuleRegulationMismatch   RuleCode = "regulation-mismatch"
+	RuleJurisdictionCycle    RuleCode = "jurisdiction-cycle"
+	RuleJurisdictionMismatch RuleCode = "jurisdiction-hierarchy-mismatch"
+	RuleInvalidExtension     RuleCode = "invalid-extension"
+)
+
+// Diagnostic is a single finding produced by framework validation.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Kind     EntityKind         `json:"kind"`
+	EntityID string             `json:"entityId"`
+	Field    string             `json:"field,omitempty"`
+	Rule     RuleCode           `json:"rule"`
+	Value    string             `json:"value,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// String returns a human-readable representation of the diagnostic.
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("%s %s %s", d.Severity, d.Kind, d.EntityID)
+	if d.Field != "" {
+		s += "." + d.Field
+	}
+	s += ": " + d.Message
+	if d.Value != "" {
+		s += fmt.Sprintf(" %q", d.Value)
+	}
+	return fmt.Sprintf("%s [%s]", s, d.Rule)
+}
+
+// ValidationReport contains the diagnostics produced by validating a framework.
+type ValidationReport struct {
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Valid returns true if the report contains no errors.
+func (vr *ValidationReport) Valid() bool {
+	return len(vr.Errors()) == 0
+}
+
+// Errors returns the diagnostics with error severity.
+func (vr *ValidationReport) Errors() []Diagnostic {
+	return vr.filter(DiagnosticError)
+}
+
+// Warnings returns the diagnostics with warning severity.
+func (vr *ValidationReport) Warnings() []Diagnostic {
+	return vr.filter(DiagnosticWarning)
+}
+
+func (vr *ValidationReport) filter(severity DiagnosticSeverity) []Diagnostic {
+	var result []Diagnostic
+	for _, d := range vr.Diagnostics {
+		if d.Severity == severity {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func (vr *ValidationReport) add(severity DiagnosticSeverity, kind EntityKind, id, field string, rule RuleCode, value, message string) {
+	vr.Diagnostics = append(vr.Diagnostics, Diagnostic{
+		Severity: severity,
+		Kind:     kind,
+		EntityID: id,
+		Field:    field,
+		Rule:     rule,
+		Value:    value,
+		Message:  message,
+	})
+}
+
+// idSet tracks entity IDs for a collection and reports missing or duplicate IDs.
+type idSet map[string]struct{}
+
+func (s idSet) has(id string) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func collectIDs(vr *ValidationReport, kind EntityKind, ids []string) idSet {
+	set := make(idSet, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			vr.add(DiagnosticError, kind, fmt.Sprintf("#%d", i), "id", RuleMissingID, "", "id is required")
+			continue
+		}
+		if set.has(id) {
+			vr.add(DiagnosticError, kind, id, "id", RuleDuplicateID, id, "duplicate id")
+			continue
+		}
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// Validate checks the referential integrity of the framework and returns
+// typed diagnostics for every broken or suspicious cross-reference.
+func (cf *ComplianceFramework) Validate() *ValidationReport {
+	return cf.ValidateWith()
+}
+
+// ValidateWith validates the framework like Validate and also checks the
+// extensions of every entity with the given validators.
+func (cf *ComplianceFramework) ValidateWith(validators ...ExtensionValidator) *ValidationReport {
+	vr := &ValidationReport{}
+
+	jurisdictionIDs := collectIDs(vr, KindJurisdiction, mapIDs(cf.Jurisdictions, func(j Jurisdiction) string { return j.ID }))
+	regulationIDs := collectIDs(vr, KindRegulation, mapIDs(cf.Regulations, func(r Regulation) string { return r.ID }))
+	requirementIDs := collectIDs(vr, KindRequirement, mapIDs(cf.Requirements, func(r Requirement) string { return r.ID }))
+	solutionIDs := collectIDs(vr, KindSolution, mapIDs(cf.Solutions, func(s Solution) string { return s.ID }))
+	collectIDs(vr, KindRegulatedEntity, mapIDs(cf.RegulatedEntities, func(e RegulatedEntity) string { return e.ID }))
+	collectIDs(vr, KindZoneAssignment, mapIDs(cf.ZoneAssignments, func(za ZoneAssignment) string { return za.ID }))
+	collectIDs(vr, KindMapping, mapIDs(cf.Mappings, func(m RequirementMapping) string { return m.ID }))
+	collectIDs(vr, KindEnforcementAssessment, mapIDs(cf.EnforcementAssessments, func(ea EnforcementAssessment) string { return ea.ID }))
+
+	cf.validateJurisdictions(vr, jurisdictionIDs)
+
+	// sectionRegulation maps section ID to the owning regulation ID.
+	sectionRegulation := make(map[string]string)
+	for _, r := range cf.Regulations {
+		for _, s := range r.Sections {
+			if s.ID == "" {
+				vr.add(DiagnosticError, KindSection, r.ID, "sections.id", RuleMissingID, "", "section id is required")
+				continue
+			}
+			if _, ok := sectionRegulation[s.ID]; ok {
+				vr.add(DiagnosticError, KindSection, s.ID, "id", RuleDuplicateID, s.ID, "duplicate section id")
+				continue
+			}
+			sectionRegulation[s.ID] = r.ID
+		}
+	}
+
+	for _, r := range cf.Regulations {
+		if r.JurisdictionID != "" && !jurisdictionIDs.has(r.JurisdictionID) {
+			vr.add(DiagnosticError, KindRegulation, r.ID, "jurisdictionId", RuleUnknownJurisdiction, r.JurisdictionID, "references unknown jurisdiction")
+		}
+		for _, s := range r.Sections {
+			if s.RegulationID != "" && s.RegulationID != r.ID {
+				vr.add(DiagnosticError, KindSection, s.ID, "regulationId", RuleRegulationMismatch, s.RegulationID,
+					fmt.Sprintf("section is nested under regulation %s", r.ID))
+			}
+			if s.ParentID != "" {
+				if s.ParentID == s.ID {
+					vr.add(DiagnosticError, KindSection, s.ID, "parentId", RuleSelfReference, s.ParentID, "section cannot be its own parent")
+				} else if owner, ok := sectionRegulation[s.ParentID]; !ok {
+					vr.add(DiagnosticError, KindSection, s.ID, "parentId", RuleUnknownSection, s.ParentID, "references unknown parent section")
+				} else if owner != r.ID {
+					vr.add(DiagnosticError, KindSection, s.ID, "parentId", RuleRegulationMismatch, s.ParentID,
+						fmt.Sprintf("parent section belongs to regulation %s", owner))
+				}
+			}
+			for _, reqID := range s.RequirementIDs {
+				if !requirementIDs.has(reqID) {
+					vr.add(DiagnosticError, KindSection, s.ID, "requirementIds", RuleUnknownRequirement, reqID, "references unknown requirement")
+				}
+			}
+		}
+		for _, e := range r.RegulatedEntities {
+			if e.RegulationID != "" && e.RegulationID != r.ID {
+				vr.add(DiagnosticError, KindRegulatedEntity, e.ID, "regulationId", RuleRegulationMismatch, e.RegulationID,
+					fmt.Sprintf("entity is nested under regulation %s", r.ID))
+			}
+		}
+	}
+
+	for _, e := range cf.RegulatedEntities {
+		if e.RegulationID == "" {
+			continue
+		}
+		if !regulationIDs.has(e.RegulationID) {
+			vr.add(DiagnosticError, KindRegulatedEntity, e.ID, "regulationId", RuleUnknownRegulation, e.RegulationID, "references unknown regulation")
+		}
+	}
+
+	for _, req := range cf.Requirements {
+		if req.RegulationID != "" && !regulationIDs.has(req.RegulationID) {
+			vr.add(DiagnosticError, KindRequirement, req.ID, "regulationId", RuleUnknownRegulation, req.RegulationID, "references unknown regulation")
+		}
+		if req.SectionID != "" {
+			if owner, ok := sectionRegulation[req.SectionID]; !ok {
+				// Regulations without modeled sections use sectionId as a citation only.
+				severity := DiagnosticError
+				if reg := cf.GetRegulation(req.RegulationID); reg != nil && len(reg.Sections) == 0 {
+					severity = DiagnosticWarning
+				}
+				vr.add(severity, KindRequirement, req.ID, "sectionId", RuleUnknownSection, req.SectionID, "references unknown section")
+			} else if req.RegulationID != "" && owner != req.RegulationID {
+				vr.add(DiagnosticError, KindRequirement, req.ID, "sectionId", RuleRegulationMismatch, req.SectionID,
+					fmt.Sprintf("section belongs to regulation %s", owner))
+			}
+		}
+		for _, relID := range req.RelatedIDs {
+			if relID == req.ID {
+				vr.add(DiagnosticWarning, KindRequirement, req.ID, "relatedIds", RuleSelfReference, relID, "requirement lists itself as related")
+			} else if !requirementIDs.has(relID) {
+				vr.add(DiagnosticError, KindRequirement, req.ID, "relatedIds", RuleUnknownRequirement, relID, "references unknown requirement")
+			}
+		}
+	}
+
+	for _, s := range cf.Solutions {
+		for _, jurID := range s.JurisdictionIDs {
+			if !jurisdictionIDs.has(jurID) {
+				vr.add(DiagnosticError, KindSolution, s.ID, "jurisdictionIds", RuleUnknownJurisdiction, jurID, "references unknown jurisdiction")
+			}
+		}
+	}
+
+	for _, za := range cf.ZoneAssignments {
+		if !solutionIDs.has(za.SolutionID) {
+			vr.add(DiagnosticError, KindZoneAssignment, za.ID, "solutionId", RuleUnknownSolution, za.SolutionID, "references unknown solution")
+		}
+		if !jurisdictionIDs.has(za.JurisdictionID) {
+			vr.add(DiagnosticError, KindZoneAssignment, za.ID, "jurisdictionId", RuleUnknownJurisdiction, za.JurisdictionID, "references unknown jurisdiction")
+		}
+		for _, regID := range za.RegulationIDs {
+			if !regulationIDs.has(regID) {
+				vr.add(DiagnosticError, KindZoneAssignment, za.ID, "regulationIds", RuleUnknownRegulation, regID, "references unknown regulation")
+			}
+		}
+	}
+
+	for _, m := range cf.Mappings {
+		if !solutionIDs.has(m.SolutionID) {
+			vr.add(DiagnosticError, KindMapping, m.ID, "solutionId", RuleUnknownSolution, m.SolutionID, "references unknown solution")
+		}
+		if !requirementIDs.has(m.RequirementID) {
+			vr.add(DiagnosticError, KindMapping, m.ID, "requirementId", RuleUnknownRequirement, m.RequirementID, "references unknown requirement")
+		}
+		for _, jurID := range m.JurisdictionIDs {
+			if !jurisdictionIDs.has(jurID) {
+				vr.add(DiagnosticError, KindMapping, m.ID, "jurisdictionIds", RuleUnknownJurisdiction, jurID, "references unknown jurisdiction")
+			}
+		}
+	}
+
+	for _, ea := range cf.EnforcementAssessments {
+		if !jurisdictionIDs.has(ea.JurisdictionID) {
+			vr.add(DiagnosticError, KindEnforcementAssessment, ea.ID, "jurisdictionId", RuleUnknownJurisdiction, ea.JurisdictionID, "references unknown jurisdiction")
+		}
+		if ea.RegulationID != "" && !regulationIDs.has(ea.RegulationID) {
+			vr.add(DiagnosticError, KindEnforcementAssessment, ea.ID, "regulationId", RuleUnknownRegulation, ea.RegulationID, "references unknown regulation")
+		}
+		if ea.RequirementID != "" {
+			if !requirementIDs.has(ea.RequirementID) {
+				vr.add(DiagnosticError, KindEnforcementAssessment, ea.ID, "requirementId", RuleUnknownRequirement, ea.RequirementID, "references unknown requirement")
+			} else if req := cf.GetRequirement(ea.RequirementID); ea.RegulationID != "" && req.RegulationID != "" && req.RegulationID != ea.RegulationID {
+				vr.add(DiagnosticWarning, KindEnforcementAssessment, ea.ID, "regulationId", RuleRegulationMismatch, ea.RegulationID,
+					fmt.Sprintf("requirement %s belongs to regulation %s", ea.RequirementID, req.RegulationID))
+			}
+		}
+	}
+
+	cf.validateExtensions(vr, validators)
+	return vr
+}
+
+func (cf *ComplianceFramework) validateJurisdictions(vr *ValidationReport, jurisdictionIDs idSet) {
+	parents := make(map[string]string)
+	for _, j := range cf.Jurisdictions {
+		if j.ParentID != "" {
+			switch {
+			case j.ParentID == j.ID:
+				vr.add(DiagnosticError, KindJurisdiction, j.ID, "parentId", RuleSelfReference, j.ParentID, "jurisdiction cannot be its own parent")
+			case !jurisdictionIDs.has(j.ParentID):
+				vr.add(DiagnosticError, KindJurisdiction, j.ID, "parentId", RuleUnknownJurisdiction, j.ParentID, "references unknown parent jurisdiction")
+			default:
+				parents[j.ID] = j.ParentID
+				parent := cf.GetJurisdiction(j.ParentID)
+				if len(parent.MemberIDs) > 0 && !slices.Contains(parent.MemberIDs, j.ID) {
+					vr.add(DiagnosticWarning, KindJurisdiction, j.ID, "parentId", RuleJurisdictionMismatch, j.ParentID,
+						fmt.Sprintf("parent %s does not list this jurisdiction in memberIds", j.ParentID))
+				}
+			}
+		}
+		for _, memberID := range j.MemberIDs {
+			if memberID == j.ID {
+				vr.add(DiagnosticError, KindJurisdiction, j.ID, "memberIds", RuleSelfReference, memberID, "jurisdiction cannot be its own member")
+				continue
+			}
+			member := cf.GetJurisdiction(memberID)
+			if member == nil {
+				// Members are often listed for documentation before they are modeled.
+				vr.add(DiagnosticWarning, KindJurisdiction, j.ID, "memberIds", RuleUnknownJurisdiction, memberID, "references unknown member jurisdiction")
+				continue
+			}
+			if member.ParentID != "" && member.ParentID != j.ID {
+				vr.add(DiagnosticWarning, KindJurisdiction, j.ID, "memberIds", RuleJurisdictionMismatch, memberID,
+					fmt.Sprintf("member %s has parentId %s", memberID, member.ParentID))
+			}
+		}
+	}
+
+	// Detect cycles in the parent chain; report each cycle once at its smallest ID.
+	reported := make(map[string]bool)
+	ids := make([]string, 0, len(parents))
+	for id := range parents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, start := range ids {
+		seen := map[string]bool{start: true}
+		for cur := parents[start]; cur != ""; cur = parents[cur] {
+			if cur == start {
+				if !reported[start] {
+					vr.add(DiagnosticError, KindJurisdiction, start, "parentId", RuleJurisdictionCycle, parents[start], "parent chain forms a cycle")
+					for id := parents[start]; id != start; id = parents[id] {
+						reported[id] = true
+					}
+				}
+				break
+			}
+			if seen[cur] {
+				break
+			}
+			seen[cur] = true
+		}
+	}
+}
+
+func mapIDs[T any](items []T, id func(T) string) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = id(item)
+	}
+	return ids
+}
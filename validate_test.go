@@ -0,0 +1,135 @@
+package comply
+
+import (
+	"testing"
+)
+
+func validTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Name: "European Union", Type: JurisdictionSupranational, MemberIDs: []string{"FR", "DE"}},
+			{ID: "FR", Name: "France", Type: JurisdictionCountry, ParentID: "EU"},
+			{ID: "DE", Name: "Germany", Type: JurisdictionCountry, ParentID: "EU"},
+		},
+		Regulations: []Regulation{
+			{
+				ID:             "EU-NIS2",
+				JurisdictionID: "EU",
+				Sections: []Section{
+					{ID: "NIS2-ART21", RegulationID: "EU-NIS2", RequirementIDs: []string{"REQ-001"}},
+				},
+			},
+		},
+		Requirements: []Requirement{
+			{ID: "REQ-001", RegulationID: "EU-NIS2", SectionID: "NIS2-ART21", RelatedIDs: []string{"REQ-002"}},
+			{ID: "REQ-002", RegulationID: "EU-NIS2"},
+		},
+		RegulatedEntities: []RegulatedEntity{
+			{ID: "NIS2-ESSENTIAL", RegulationID: "EU-NIS2"},
+		},
+		Solutions: []Solution{
+			{ID: "aws", JurisdictionIDs: []string{"EU"}},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "aws", JurisdictionID: "FR", Zone: ZoneRed, RegulationIDs: []string{"EU-NIS2"}},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", JurisdictionIDs: []string{"FR"}},
+		},
+		EnforcementAssessments: []EnforcementAssessment{
+			{ID: "E1", JurisdictionID: "FR", RegulationID: "EU-NIS2", RequirementID: "REQ-001"},
+		},
+	}
+}
+
+func hasDiagnostic(report *ValidationReport, kind EntityKind, id string, rule RuleCode) bool {
+	for _, d := range report.Diagnostics {
+		if d.Kind == kind && d.EntityID == id && d.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateValidFramework(t *testing.T) {
+	report := validTestFramework().Validate()
+	if !report.Valid() {
+		t.Errorf("expected valid framework, got errors: %v", report.Errors())
+	}
+	if len(report.Warnings()) != 0 {
+		t.Errorf("expected no warnings, got %v", report.Warnings())
+	}
+}
+
+func TestValidateBrokenReferences(t *testing.T) {
+	cf := validTestFramework()
+	cf.Regulations[0].Sections[0].RequirementIDs = append(cf.Regulations[0].Sections[0].RequirementIDs, "REQ-404")
+	cf.Requirements[1].RelatedIDs = []string{"REQ-404"}
+	cf.Jurisdictions = append(cf.Jurisdictions, Jurisdiction{ID: "XX", ParentID: "NOWHERE"})
+	cf.EnforcementAssessments[0].RegulationID = "EU-UNKNOWN"
+	cf.ZoneAssignments[0].RegulationIDs = []string{"EU-UNKNOWN"}
+	cf.RegulatedEntities[0].RegulationID = "EU-UNKNOWN"
+	cf.Mappings = append(cf.Mappings, RequirementMapping{ID: "M1", RequirementID: "REQ-001", SolutionID: "gcp"})
+
+	report := cf.Validate()
+	if report.Valid() {
+		t.Fatal("expected validation errors")
+	}
+
+	tests := []struct {
+		kind EntityKind
+		id   string
+		rule RuleCode
+	}{
+		{KindSection, "NIS2-ART21", RuleUnknownRequirement},
+		{KindRequirement, "REQ-002", RuleUnknownRequirement},
+		{KindJurisdiction, "XX", RuleUnknownJurisdiction},
+		{KindEnforcementAssessment, "E1", RuleUnknownRegulation},
+		{KindZoneAssignment, "Z1", RuleUnknownRegulation},
+		{KindRegulatedEntity, "NIS2-ESSENTIAL", RuleUnknownRegulation},
+		{KindMapping, "M1", RuleDuplicateID},
+		{KindMapping, "M1", RuleUnknownSolution},
+	}
+	for _, tt := range tests {
+		if !hasDiagnostic(report, tt.kind, tt.id, tt.rule) {
+			t.Errorf("expected %s diagnostic for %s %s", tt.rule, tt.kind, tt.id)
+		}
+	}
+}
+
+func TestValidateJurisdictionCycle(t *testing.T) {
+	cf := &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "A", ParentID: "B"},
+			{ID: "B", ParentID: "C"},
+			{ID: "C", ParentID: "A"},
+		},
+	}
+
+	report := cf.Validate()
+	var cycles int
+	for _, d := range report.Errors() {
+		if d.Rule == RuleJurisdictionCycle {
+			cycles++
+		}
+	}
+	if cycles != 1 {
+		t.Errorf("expected 1 cycle diagnostic, got %d", cycles)
+	}
+}
+
+func TestValidateHierarchyMismatchIsWarning(t *testing.T) {
+	cf := validTestFramework()
+	cf.Jurisdictions[0].MemberIDs = []string{"FR", "AT"}
+
+	report := cf.Validate()
+	if !report.Valid() {
+		t.Errorf("expected no errors, got %v", report.Errors())
+	}
+	if !hasDiagnostic(report, KindJurisdiction, "DE", RuleJurisdictionMismatch) {
+		t.Error("expected hierarchy mismatch warning for DE")
+	}
+	if !hasDiagnostic(report, KindJurisdiction, "EU", RuleUnknownJurisdiction) {
+		t.Error("expected unknown member warning for EU")
+	}
+}
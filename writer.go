@@ -0,0 +1,216 @@
+package comply
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FileWriter is a destination for framework files. Names are slash-separated
+// paths relative to the destination root, as in fs.FS.
+type FileWriter interface {
+	WriteFile(name string, data []byte) error
+}
+
+// WriteJSONTo marshals v and writes it to name in w.
+func WriteJSONTo(w FileWriter, name string, v any, indent bool) error {
+	data, err := marshalJSON(v, indent)
+	if err != nil {
+		return err
+	}
+	return w.WriteFile(name, data)
+}
+
+// DirWriter writes files under a directory, creating subdirectories as needed.
+type DirWriter string
+
+// WriteFile atomically writes data to name under the directory.
+func (d DirWriter) WriteFile(name string, data []byte) error {
+	path := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", filepath.Dir(path), err)
+	}
+	return WriteFileAtomic(path, data)
+}
+
+// MapFSWriter writes files into memory, keyed by name. It is also an fs.FS,
+// so LoadFrameworkFS can read the files back, which is useful for in-memory
+// round trips and tests. Directories are implied by the file names.
+type MapFSWriter map[string][]byte
+
+// WriteFile stores a copy of data under name.
+func (m MapFSWriter) WriteFile(name string, data []byte) error {
+	m[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Open opens the named file or implied directory.
+func (m MapFSWriter) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m[name]; ok {
+		return &memFile{info: memFileInfo{name: path.Base(name), size: int64(len(data)), mode: 0644}, data: data}, nil
+	}
+
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for file, data := range m {
+		rest, ok := strings.CutPrefix(file, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		child, _, isDir := strings.Cut(rest, "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		info := memFileInfo{name: child, size: int64(len(data)), mode: 0644}
+		if isDir {
+			info = memFileInfo{name: child, mode: fs.ModeDir | 0755}
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return &memDir{info: memFileInfo{name: path.Base(name), mode: fs.ModeDir | 0755}, entries: entries}, nil
+}
+
+// memFileInfo describes a file or directory of a MapFSWriter.
+type memFileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi memFileInfo) Sys() any           { return nil }
+
+// memFile is an open file of a MapFSWriter.
+type memFile struct {
+	info   memFileInfo
+	data   []byte
+	offset int
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+func (f *memFile) Read(b []byte) (int, error) {
+	if f.offset >= len(f.data) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.data[f.offset:])
+	f.offset += n
+	return n, nil
+}
+
+// memDir is an open directory of a MapFSWriter.
+type memDir struct {
+	info    memFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(rest) {
+		rest = rest[:n]
+	}
+	d.offset += len(rest)
+	return rest, nil
+}
+
+// ZipWriter writes files into a zip archive. Close must be called to write
+// the archive's central directory.
+type ZipWriter struct {
+	zw *zip.Writer
+}
+
+// NewZipWriter returns a ZipWriter writing an archive to w.
+func NewZipWriter(w io.Writer) *ZipWriter {
+	return &ZipWriter{zw: zip.NewWriter(w)}
+}
+
+// WriteFile adds a compressed file to the archive.
+func (z *ZipWriter) WriteFile(name string, data []byte) error {
+	f, err := z.zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("adding %s to archive: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// Close finishes the archive. It does not close the underlying writer.
+func (z *ZipWriter) Close() error {
+	return z.zw.Close()
+}
+
+// fileReader is implemented by writers that can read back a previous file,
+// so that YAML comments survive a save.
+type fileReader interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+// ReadFile reads a file under the directory.
+func (d DirWriter) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(d), filepath.FromSlash(name)))
+}
+
+// ReadFile returns a copy of the data stored under name.
+func (m MapFSWriter) ReadFile(name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// recordingWriter records the names of the files written through it.
+type recordingWriter struct {
+	FileWriter
+	written map[string]bool
+}
+
+func (r *recordingWriter) WriteFile(name string, data []byte) error {
+	r.written[name] = true
+	return r.FileWriter.WriteFile(name, data)
+}
+
+// ReadFile reads back a previous file when the underlying writer can.
+func (r *recordingWriter) ReadFile(name string) ([]byte, error) {
+	if fr, ok := r.FileWriter.(fileReader); ok {
+		return fr.ReadFile(name)
+	}
+	return nil, fs.ErrNotExist
+}
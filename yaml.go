@@ -0,0 +1,219 @@
+package comply
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// yamlToJSON converts a YAML document to JSON so that it decodes through the
+// same JSON tags and enum validation as JSON files. Timestamps are kept as
+// the strings they were written as, and so are unquoted numbers and
+// booleans where t, the type the document decodes into, has a string.
+func yamlToJSON(data []byte, t reflect.Type) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return []byte("null"), nil
+	}
+	v, err := yamlValue(doc.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		v = yamlStrings(t, v)
+	}
+	return json.Marshal(v)
+}
+
+// yamlStrings converts the scalars of v back to the text they were written
+// as where t has a string, so that "eta: 2026" or "version: 1.0" load into
+// string fields.
+func yamlStrings(t reflect.Type, v any) any {
+	var walk func(t reflect.Type, v any, path string) (any, bool)
+	walk = func(t reflect.Type, v any, path string) (any, bool) {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.String:
+			switch s := v.(type) {
+			case json.Number:
+				return string(s), true
+			case bool:
+				return strconv.FormatBool(s), true
+			}
+		case reflect.Struct:
+			if obj, ok := v.(map[string]any); ok {
+				walkFields(t, obj, path, walk)
+			}
+		case reflect.Slice, reflect.Array:
+			if arr, ok := v.([]any); ok {
+				for i, elem := range arr {
+					arr[i], _ = walk(t.Elem(), elem, path)
+				}
+			}
+		case reflect.Map:
+			if obj, ok := v.(map[string]any); ok {
+				for key, elem := range obj {
+					obj[key], _ = walk(t.Elem(), elem, path)
+				}
+			}
+		}
+		return v, true
+	}
+	v, _ = walk(t, v, "$")
+	return v
+}
+
+func yamlValue(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]any, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := yamlValue(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!timestamp", "!!str":
+			return n.Value, nil
+		case "!!int", "!!float":
+			return json.Number(n.Value), nil
+		}
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
+// jsonToYAML converts JSON to block-style YAML, keeping the field order of
+// the JSON. Comments are carried over from existing, the previous YAML of the
+// same file, where its keys and entity IDs still match.
+func jsonToYAML(data, existing []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	blockStyle(&doc)
+
+	if len(existing) > 0 {
+		var old yaml.Node
+		if err := yaml.Unmarshal(existing, &old); err == nil {
+			mergeComments(&old, &doc)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles that come from JSON, so the
+// encoder writes block YAML and quotes only where needed.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" && strings.Contains(n.Value, "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// mergeComments copies comments from old to the matching nodes of n.
+// Mapping values are matched by key and sequence items by their "id" field,
+// falling back to their position.
+func mergeComments(old, n *yaml.Node) {
+	if n.HeadComment == "" {
+		n.HeadComment = old.HeadComment
+	}
+	if n.LineComment == "" {
+		n.LineComment = old.LineComment
+	}
+	if n.FootComment == "" {
+		n.FootComment = old.FootComment
+	}
+	if old.Kind != n.Kind {
+		return
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(old.Content) > 0 && len(n.Content) > 0 {
+			mergeComments(old.Content[0], n.Content[0])
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			for j := 0; j+1 < len(old.Content); j += 2 {
+				if old.Content[j].Value == n.Content[i].Value {
+					mergeComments(old.Content[j], n.Content[i])
+					mergeComments(old.Content[j+1], n.Content[i+1])
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		byID := make(map[string]*yaml.Node)
+		for _, c := range old.Content {
+			if id := yamlID(c); id != "" {
+				byID[id] = c
+			}
+		}
+		for i, c := range n.Content {
+			if id := yamlID(c); id != "" {
+				if o, ok := byID[id]; ok {
+					mergeComments(o, c)
+				}
+			} else if i < len(old.Content) {
+				mergeComments(old.Content[i], c)
+			}
+		}
+	}
+}
+
+// yamlID returns the "id" field of a mapping node, or "".
+func yamlID(n *yaml.Node) string {
+	if n.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "id" {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package comply
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func yamlTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Name:        "YAML Test",
+		Version:     "1.0.0",
+		LastUpdated: "2026-01-15",
+		Requirements: []Requirement{
+			{ID: "REQ-001", Name: "Residency", Description: "Data stays in the EU.\nIncludes backups.", Severity: SeverityHigh},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "REQ-001", SolutionID: "aws", ComplianceLevel: CompliancePartial, ETA: "2026"},
+			{ID: "M2", RequirementID: "REQ-001", SolutionID: "ovh", ComplianceLevel: ComplianceFull},
+		},
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	original := yamlTestFramework()
+
+	w := MapFSWriter{}
+	if err := (&Saver{Format: FormatYAML}).SaveFramework(original, w); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+	if _, ok := w["mappings.yaml"]; !ok {
+		t.Fatalf("expected mappings.yaml, got %v", w)
+	}
+	loaded, err := LoadFrameworkFS(w)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	if d := Diff(original, loaded); !d.Empty() {
+		t.Errorf("expected YAML round trip without changes, got:\n%s", d.Text())
+	}
+
+	for _, name := range []string{"bundle.yaml", "bundle.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := SaveBundle(loaded, path); err != nil {
+			t.Fatalf("SaveBundle(%s) failed: %v", name, err)
+		}
+		bundle, err := LoadFramework(path)
+		if err != nil {
+			t.Fatalf("LoadFramework(%s) failed: %v", name, err)
+		}
+		if d := Diff(original, bundle); !d.Empty() {
+			t.Errorf("expected %s round trip without changes, got:\n%s", name, d.Text())
+		}
+	}
+}
+
+func TestYAMLComments(t *testing.T) {
+	w := MapFSWriter{}
+	w.WriteFile("mappings.yaml", []byte(`# Reviewed by the compliance team.
+- id: M2
+  complianceLevel: compliant # SecNumCloud qualified
+- id: M1
+  # Pending the EU sovereign region.
+  complianceLevel: partial
+`))
+
+	cf := yamlTestFramework()
+	cf.Mappings[0].ComplianceLevel = ComplianceConditional
+	if err := (&Saver{Format: FormatYAML}).SaveFramework(cf, w); err != nil {
+		t.Fatalf("SaveFramework failed: %v", err)
+	}
+
+	out := string(w["mappings.yaml"])
+	for _, want := range []string{
+		"# Reviewed by the compliance team.",
+		"complianceLevel: compliant # SecNumCloud qualified",
+		"  # Pending the EU sovereign region.\n  complianceLevel: conditional",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected saved YAML to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoadYAMLFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"framework.yml":  {Data: []byte("name: YAML Test\nversion: 1.0\n")},
+		"mappings.yaml":  {Data: []byte("- id: M1\n  complianceLevel: partial\n  assessmentDate: 2025-05-01\n  eta: 2026\n")},
+		"solutions.yaml": {Data: []byte("- id: aws\n  name: 2026\n  provider: true\n  certifications: [27001]\n")},
+	}
+	cf, err := LoadFrameworkFS(fsys)
+	if err != nil {
+		t.Fatalf("LoadFrameworkFS failed: %v", err)
+	}
+	if cf.Version != "1.0" || len(cf.Mappings) != 1 || cf.Mappings[0].AssessmentDate != "2025-05-01" || cf.Mappings[0].ETA != "2026" {
+		t.Errorf("unexpected framework %+v", cf)
+	}
+	if len(cf.Solutions) != 1 || cf.Solutions[0].Name != "2026" || cf.Solutions[0].Provider != "true" || cf.Solutions[0].Certifications[0] != "27001" {
+		t.Errorf("expected unquoted scalars as strings, got %+v", cf.Solutions)
+	}
+
+	fsys["solutions.yaml"] = &fstest.MapFile{Data: []byte("- id: aws\n  name: [a, b]\n")}
+	if _, err := LoadFrameworkFS(fsys); err == nil || !strings.Contains(err.Error(), "solutions.yaml") {
+		t.Errorf("expected type error for solutions.yaml, got %v", err)
+	}
+	delete(fsys, "solutions.yaml")
+
+	fsys["mappings.json"] = &fstest.MapFile{Data: []byte("[]")}
+	if _, err := LoadFrameworkFS(fsys); err == nil || !strings.Contains(err.Error(), "ambiguous mappings") {
+		t.Errorf("expected ambiguous mappings error, got %v", err)
+	}
+}
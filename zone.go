@@ -1,5 +1,7 @@
 package comply
 
+import "slices"
+
 // ComplianceZone represents the compliance zone classification.
 type ComplianceZone string
 
@@ -17,14 +19,41 @@ const (
 	ZoneGreen ComplianceZone = "green"
 )
 
+// ComplianceZones lists the valid ComplianceZone values.
+var ComplianceZones = registerEnum(ZoneRed, ZoneYellow, ZoneGreen)
+
+// Valid reports whether z is a known compliance zone.
+func (z ComplianceZone) Valid() bool { return slices.Contains(ComplianceZones, z) }
+
+// UnmarshalJSON decodes a compliance zone, rejecting unknown values with an *EnumError.
+func (z *ComplianceZone) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, z, ComplianceZones)
+}
+
 // ZoneAssignment assigns a compliance zone to a solution in a jurisdiction.
 type ZoneAssignment struct {
 	ID             string         `json:"id"`
 	SolutionID     string         `json:"solutionId"`
 	JurisdictionID string         `json:"jurisdictionId"`
 	Zone           ComplianceZone `json:"zone"`
-	DataCategory   string         `json:"dataCategory,omitempty"`  // e.g., "essential", "personal", "general"
-	EntityType     string         `json:"entityType,omitempty"`    // e.g., "essential-entity", "financial-services"
+	DataCategory   string         `json:"dataCategory,omitempty"` // e.g., "essential", "personal", "general"
+	EntityType     string         `json:"entityType,omitempty"`   // e.g., "essential-entity", "financial-services"
 	Rationale      string         `json:"rationale,omitempty"`
 	RegulationIDs  []string       `json:"regulationIds,omitempty"` // Regulations driving this zone
+	Extensions     Extensions     `json:"extensions,omitempty"`
+	unknown        unknownFields
+}
+
+// UnmarshalJSON decodes a zone assignment, keeping the fields it does not know.
+func (z *ZoneAssignment) UnmarshalJSON(data []byte) error {
+	type plain ZoneAssignment
+	var err error
+	z.unknown, err = unmarshalKnown(data, (*plain)(z), "ZoneAssignment")
+	return err
+}
+
+// MarshalJSON encodes a zone assignment, including the unknown fields it was decoded with.
+func (z ZoneAssignment) MarshalJSON() ([]byte, error) {
+	type plain ZoneAssignment
+	return marshalKnown(plain(z), z.unknown)
 }
@@ -0,0 +1,397 @@
+package comply
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ZoneRuleSet is a declarative, ordered set of rules that derives compliance
+// zones per (solution, jurisdiction, data category, entity type). Rules are
+// evaluated in order and the first matching rule decides the zone.
+type ZoneRuleSet struct {
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Scopes      []ZoneScope    `json:"scopes,omitempty"`      // defaults to the cells of the hand-entered zone assignments
+	DefaultZone ComplianceZone `json:"defaultZone,omitempty"` // zone when no rule matches; empty leaves the cell unassigned
+	Rules       []ZoneRule     `json:"rules"`
+}
+
+// ZoneScope is a (jurisdiction, data category, entity type) cell to evaluate.
+type ZoneScope struct {
+	JurisdictionID string   `json:"jurisdictionId"`
+	DataCategory   string   `json:"dataCategory,omitempty"`
+	EntityType     string   `json:"entityType,omitempty"`
+	SolutionIDs    []string `json:"solutionIds,omitempty"` // defaults to solutions available in the jurisdiction
+}
+
+// ZoneRule assigns a zone when its condition matches.
+type ZoneRule struct {
+	ID            string         `json:"id"`
+	Description   string         `json:"description,omitempty"`
+	When          ZoneCondition  `json:"when"`
+	Zone          ComplianceZone `json:"zone"`
+	Rationale     string         `json:"rationale,omitempty"`
+	RegulationIDs []string       `json:"regulationIds,omitempty"`
+}
+
+// ZoneCondition tests a solution within a scope. All set fields must match.
+type ZoneCondition struct {
+	JurisdictionIDs []string            `json:"jurisdictionIds,omitempty"` // matches these jurisdictions and their descendants
+	DataCategories  []string            `json:"dataCategories,omitempty"`
+	EntityTypes     []string            `json:"entityTypes,omitempty"`
+	SolutionIDs     []string            `json:"solutionIds,omitempty"`
+	SolutionTypes   []SolutionType      `json:"solutionTypes,omitempty"`
+	Certifications  []string            `json:"certifications,omitempty"` // solution holds all of these
+	Ownership       *OwnershipCondition `json:"ownership,omitempty"`
+	Mappings        []MappingCondition  `json:"mappings,omitempty"`
+	Any             []ZoneCondition     `json:"any,omitempty"` // at least one must match
+	Not             *ZoneCondition      `json:"not,omitempty"`
+}
+
+// OwnershipCondition tests a solution's OwnershipStructure. A solution without
+// ownership data never matches.
+type OwnershipCondition struct {
+	MinEUOwnershipPercent        *float64 `json:"minEuOwnershipPercent,omitempty"`
+	MaxLargestNonEUPercent       *float64 `json:"maxLargestNonEuPercent,omitempty"`
+	SubjectToExtraTerritorialLaw *bool    `json:"subjectToExtraTerritorialLaw,omitempty"`
+}
+
+// MappingMatch selects how many of the selected mappings must match.
+type MappingMatch string
+
+const (
+	MatchAny MappingMatch = "any" // at least one mapping, the default
+	MatchAll MappingMatch = "all" // every mapping, and at least one
+)
+
+// MappingMatches lists the valid MappingMatch values.
+var MappingMatches = registerEnum(MatchAny, MatchAll)
+
+// Valid reports whether m is a known mapping match.
+func (m MappingMatch) Valid() bool { return slices.Contains(MappingMatches, m) }
+
+// UnmarshalJSON decodes a mapping match, rejecting unknown values with an *EnumError.
+func (m *MappingMatch) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, m, MappingMatches)
+}
+
+// MappingCondition tests the effective mappings of a solution in the scope
+// jurisdiction for the selected requirements.
+type MappingCondition struct {
+	RequirementIDs []string          `json:"requirementIds,omitempty"`
+	RegulationIDs  []string          `json:"regulationIds,omitempty"`
+	Levels         []ComplianceLevel `json:"levels"`
+	Match          MappingMatch      `json:"match,omitempty"` // MatchAny when empty
+}
+
+// DerivedZoneAssignment is a computed zone assignment and the rule that produced it.
+type DerivedZoneAssignment struct {
+	ZoneAssignment
+	RuleID string `json:"ruleId,omitempty"`
+}
+
+// MarshalJSON encodes the zone assignment followed by the rule ID.
+func (d DerivedZoneAssignment) MarshalJSON() ([]byte, error) {
+	return marshalEmbedded(d.ZoneAssignment, struct {
+		RuleID string `json:"ruleId,omitempty"`
+	}{d.RuleID})
+}
+
+// ZoneDisagreementKind classifies a difference between computed and hand-entered zones.
+type ZoneDisagreementKind string
+
+const (
+	ZoneMismatch    ZoneDisagreementKind = "mismatch"     // both exist with different zones
+	ZoneNotComputed ZoneDisagreementKind = "not-computed" // hand-entered, but no rule matched
+	ZoneNotAssigned ZoneDisagreementKind = "not-assigned" // computed, but not hand-entered
+)
+
+// ZoneDisagreement reports a cell where computed and hand-entered zones differ.
+type ZoneDisagreement struct {
+	Kind           ZoneDisagreementKind `json:"kind"`
+	SolutionID     string               `json:"solutionId"`
+	JurisdictionID string               `json:"jurisdictionId"`
+	DataCategory   string               `json:"dataCategory,omitempty"`
+	EntityType     string               `json:"entityType,omitempty"`
+	ManualZone     ComplianceZone       `json:"manualZone,omitempty"`
+	ComputedZone   ComplianceZone       `json:"computedZone,omitempty"`
+	ManualID       string               `json:"manualId,omitempty"`
+	Inherited      bool                 `json:"inherited,omitempty"` // hand-entered zone is inherited from a parent jurisdiction
+	RuleID         string               `json:"ruleId,omitempty"`
+}
+
+// ZoneDerivation is the result of evaluating a ZoneRuleSet.
+type ZoneDerivation struct {
+	Assignments   []DerivedZoneAssignment `json:"assignments"`
+	Disagreements []ZoneDisagreement      `json:"disagreements,omitempty"`
+}
+
+// ZoneAssignments returns the computed assignments as plain ZoneAssignments.
+func (zd *ZoneDerivation) ZoneAssignments() []ZoneAssignment {
+	result := make([]ZoneAssignment, len(zd.Assignments))
+	for i, a := range zd.Assignments {
+		result[i] = a.ZoneAssignment
+	}
+	return result
+}
+
+// DeriveZones evaluates a rule set against the framework and compares the
+// computed zones with the hand-entered ZoneAssignments. A hand-entered zone
+// inherited from a parent jurisdiction is compared as well (see
+// EffectiveZoneAssignments).
+func (cf *ComplianceFramework) DeriveZones(rs *ZoneRuleSet) *ZoneDerivation {
+	ev := &zoneEvaluator{
+		cf:       cf,
+		h:        cf.Hierarchy(),
+		mappings: make(map[string][]ResolvedMapping),
+		regByReq: make(map[string]string),
+	}
+	for _, r := range cf.Requirements {
+		ev.regByReq[r.ID] = r.RegulationID
+	}
+
+	result := &ZoneDerivation{}
+	evaluated := make(map[string]bool)
+	effective := make(map[string]map[string]ResolvedZoneAssignment)
+
+	for _, scope := range cf.zoneScopes(rs, ev.h) {
+		if effective[scope.JurisdictionID] == nil {
+			effective[scope.JurisdictionID] = make(map[string]ResolvedZoneAssignment)
+			for _, z := range cf.EffectiveZoneAssignments(scope.JurisdictionID) {
+				effective[scope.JurisdictionID][zoneCellKey(z.SolutionID, z.DataCategory, z.EntityType)] = z
+			}
+		}
+
+		for _, solID := range scope.SolutionIDs {
+			sol := cf.GetSolution(solID)
+			if sol == nil {
+				continue
+			}
+			key := scope.JurisdictionID + "|" + zoneCellKey(solID, scope.DataCategory, scope.EntityType)
+			if evaluated[key] {
+				continue
+			}
+			evaluated[key] = true
+
+			derived, ok := ev.derive(rs, sol, scope)
+			manual, hasManual := effective[scope.JurisdictionID][zoneCellKey(solID, scope.DataCategory, scope.EntityType)]
+			d := ZoneDisagreement{
+				SolutionID:     solID,
+				JurisdictionID: scope.JurisdictionID,
+				DataCategory:   scope.DataCategory,
+				EntityType:     scope.EntityType,
+				ManualZone:     manual.Zone,
+				ManualID:       manual.ID,
+				Inherited:      manual.Inherited,
+			}
+
+			switch {
+			case !ok && hasManual:
+				d.Kind = ZoneNotComputed
+				result.Disagreements = append(result.Disagreements, d)
+			case !ok:
+			case !hasManual:
+				d.Kind = ZoneNotAssigned
+				d.ComputedZone = derived.Zone
+				d.RuleID = derived.RuleID
+				result.Disagreements = append(result.Disagreements, d)
+			case manual.Zone != derived.Zone:
+				d.Kind = ZoneMismatch
+				d.ComputedZone = derived.Zone
+				d.RuleID = derived.RuleID
+				result.Disagreements = append(result.Disagreements, d)
+			}
+
+			if ok {
+				result.Assignments = append(result.Assignments, derived)
+			}
+		}
+	}
+
+	return result
+}
+
+// zoneScopes returns the rule set's scopes with solution lists filled in. When
+// the rule set has no scopes, the cells of the hand-entered zone assignments are used.
+func (cf *ComplianceFramework) zoneScopes(rs *ZoneRuleSet, h *JurisdictionHierarchy) []ZoneScope {
+	scopes := rs.Scopes
+	if len(scopes) == 0 {
+		seen := make(map[string]bool)
+		for _, z := range cf.ZoneAssignments {
+			key := z.JurisdictionID + "|" + z.DataCategory + "|" + z.EntityType
+			if !seen[key] {
+				seen[key] = true
+				scopes = append(scopes, ZoneScope{JurisdictionID: z.JurisdictionID, DataCategory: z.DataCategory, EntityType: z.EntityType})
+			}
+		}
+	}
+
+	result := make([]ZoneScope, len(scopes))
+	for i, scope := range scopes {
+		if len(scope.SolutionIDs) == 0 {
+			for _, s := range cf.Solutions {
+				if _, d := h.nearest(scope.JurisdictionID, s.JurisdictionIDs); d >= 0 {
+					scope.SolutionIDs = append(scope.SolutionIDs, s.ID)
+				}
+			}
+			// Solutions with a hand-entered zone are evaluated even if not listed as available.
+			for _, z := range cf.ZoneAssignments {
+				if z.JurisdictionID == scope.JurisdictionID && z.DataCategory == scope.DataCategory && z.EntityType == scope.EntityType {
+					scope.SolutionIDs = appendUnique(scope.SolutionIDs, z.SolutionID)
+				}
+			}
+		}
+		result[i] = scope
+	}
+	return result
+}
+
+func zoneCellKey(solutionID, dataCategory, entityType string) string {
+	return solutionID + "|" + dataCategory + "|" + entityType
+}
+
+type zoneEvaluator struct {
+	cf       *ComplianceFramework
+	h        *JurisdictionHierarchy
+	mappings map[string][]ResolvedMapping // effective mappings by jurisdiction
+	regByReq map[string]string
+}
+
+func (ev *zoneEvaluator) derive(rs *ZoneRuleSet, sol *Solution, scope ZoneScope) (DerivedZoneAssignment, bool) {
+	za := ZoneAssignment{
+		ID:             zoneAssignmentID(sol.ID, scope),
+		SolutionID:     sol.ID,
+		JurisdictionID: scope.JurisdictionID,
+		DataCategory:   scope.DataCategory,
+		EntityType:     scope.EntityType,
+	}
+	for _, rule := range rs.Rules {
+		if !ev.match(&rule.When, sol, scope) {
+			continue
+		}
+		za.Zone = rule.Zone
+		za.Rationale = rule.Rationale
+		za.RegulationIDs = rule.RegulationIDs
+		return DerivedZoneAssignment{ZoneAssignment: za, RuleID: rule.ID}, true
+	}
+	if rs.DefaultZone != "" {
+		za.Zone = rs.DefaultZone
+		za.Rationale = "No rule matched; default zone"
+		return DerivedZoneAssignment{ZoneAssignment: za}, true
+	}
+	return DerivedZoneAssignment{}, false
+}
+
+func (ev *zoneEvaluator) match(c *ZoneCondition, sol *Solution, scope ZoneScope) bool {
+	if len(c.JurisdictionIDs) > 0 && !slices.ContainsFunc(c.JurisdictionIDs, func(id string) bool { return ev.h.AppliesTo(id, scope.JurisdictionID) }) {
+		return false
+	}
+	if len(c.DataCategories) > 0 && !slices.Contains(c.DataCategories, scope.DataCategory) {
+		return false
+	}
+	if len(c.EntityTypes) > 0 && !slices.Contains(c.EntityTypes, scope.EntityType) {
+		return false
+	}
+	if len(c.SolutionIDs) > 0 && !slices.Contains(c.SolutionIDs, sol.ID) {
+		return false
+	}
+	if len(c.SolutionTypes) > 0 && !slices.Contains(c.SolutionTypes, sol.Type) {
+		return false
+	}
+	if len(c.Certifications) > 0 {
+		held := normalizeAll(sol.Certifications)
+		for _, cert := range c.Certifications {
+			if !slices.Contains(held, normalize(cert)) {
+				return false
+			}
+		}
+	}
+	if c.Ownership != nil && !c.Ownership.match(sol.OwnershipStructure) {
+		return false
+	}
+	for _, mc := range c.Mappings {
+		if !ev.matchMappings(&mc, sol.ID, scope.JurisdictionID) {
+			return false
+		}
+	}
+	if len(c.Any) > 0 && !slices.ContainsFunc(c.Any, func(sub ZoneCondition) bool { return ev.match(&sub, sol, scope) }) {
+		return false
+	}
+	if c.Not != nil && ev.match(c.Not, sol, scope) {
+		return false
+	}
+	return true
+}
+
+func (oc *OwnershipCondition) match(o *OwnershipStructure) bool {
+	if o == nil {
+		return false
+	}
+	if oc.MinEUOwnershipPercent != nil && o.EUOwnershipPercent < *oc.MinEUOwnershipPercent {
+		return false
+	}
+	if oc.MaxLargestNonEUPercent != nil && o.LargestNonEUPercent > *oc.MaxLargestNonEUPercent {
+		return false
+	}
+	if oc.SubjectToExtraTerritorialLaw != nil && o.SubjectToExtraTerritorialLaw != *oc.SubjectToExtraTerritorialLaw {
+		return false
+	}
+	return true
+}
+
+func (ev *zoneEvaluator) matchMappings(mc *MappingCondition, solutionID, jurisdictionID string) bool {
+	mappings, ok := ev.mappings[jurisdictionID]
+	if !ok {
+		mappings = ev.cf.EffectiveMappings(jurisdictionID)
+		ev.mappings[jurisdictionID] = mappings
+	}
+
+	var selected int
+	for _, m := range mappings {
+		if m.SolutionID != solutionID {
+			continue
+		}
+		if len(mc.RequirementIDs) > 0 && !slices.Contains(mc.RequirementIDs, m.RequirementID) {
+			continue
+		}
+		if len(mc.RegulationIDs) > 0 && !slices.Contains(mc.RegulationIDs, ev.regByReq[m.RequirementID]) {
+			continue
+		}
+		selected++
+		matched := slices.Contains(mc.Levels, m.ComplianceLevel)
+		if matched && mc.Match != MatchAll {
+			return true
+		}
+		if !matched && mc.Match == MatchAll {
+			return false
+		}
+	}
+	return mc.Match == MatchAll && selected > 0
+}
+
+func zoneAssignmentID(solutionID string, scope ZoneScope) string {
+	parts := []string{"ZONE", solutionID, scope.JurisdictionID}
+	for _, p := range []string{scope.DataCategory, scope.EntityType} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.ToUpper(strings.Join(parts, "-"))
+}
+
+// String returns a one-line description of the disagreement.
+func (d ZoneDisagreement) String() string {
+	cell := fmt.Sprintf("%s / %s / %s", d.SolutionID, d.JurisdictionID, d.DataCategory)
+	if d.EntityType != "" {
+		cell += " / " + d.EntityType
+	}
+	switch d.Kind {
+	case ZoneMismatch:
+		return fmt.Sprintf("%s: hand-entered %s (%s), computed %s (%s)", cell, d.ManualZone, d.ManualID, d.ComputedZone, d.RuleID)
+	case ZoneNotComputed:
+		return fmt.Sprintf("%s: hand-entered %s (%s), no rule matched", cell, d.ManualZone, d.ManualID)
+	default:
+		return fmt.Sprintf("%s: computed %s (%s), not hand-entered", cell, d.ComputedZone, d.RuleID)
+	}
+}
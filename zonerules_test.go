@@ -0,0 +1,145 @@
+package comply
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func zoneRulesTestFramework() *ComplianceFramework {
+	return &ComplianceFramework{
+		Jurisdictions: []Jurisdiction{
+			{ID: "EU", Type: JurisdictionSupranational},
+			{ID: "FR", Type: JurisdictionCountry, ParentID: "EU"},
+		},
+		Requirements: []Requirement{
+			{ID: "SNC-01", RegulationID: "FR-SNC"},
+		},
+		Solutions: []Solution{
+			{ID: "aws", Type: SolutionCommercial, JurisdictionIDs: []string{"EU"},
+				OwnershipStructure: &OwnershipStructure{LargestNonEUPercent: 100, SubjectToExtraTerritorialLaw: true}},
+			{ID: "ovh", Type: SolutionSovereign, JurisdictionIDs: []string{"FR"}, Certifications: []string{"SecNumCloud"},
+				OwnershipStructure: &OwnershipStructure{EUOwnershipPercent: 100}},
+			{ID: "bleu", Type: SolutionSovereign, JurisdictionIDs: []string{"FR"},
+				OwnershipStructure: &OwnershipStructure{EUOwnershipPercent: 100}},
+		},
+		Mappings: []RequirementMapping{
+			{ID: "M1", RequirementID: "SNC-01", SolutionID: "aws", JurisdictionIDs: []string{"FR"}, ComplianceLevel: ComplianceBanned},
+		},
+		ZoneAssignments: []ZoneAssignment{
+			{ID: "Z1", SolutionID: "aws", JurisdictionID: "FR", DataCategory: "essential", Zone: ZoneRed},
+			{ID: "Z2", SolutionID: "bleu", JurisdictionID: "FR", DataCategory: "essential", Zone: ZoneGreen},
+			{ID: "Z3", SolutionID: "ovh", JurisdictionID: "EU", DataCategory: "personal", Zone: ZoneGreen},
+		},
+	}
+}
+
+func zoneRulesTestRuleSet() *ZoneRuleSet {
+	minEU := 76.0
+	return &ZoneRuleSet{
+		Scopes: []ZoneScope{
+			{JurisdictionID: "FR", DataCategory: "essential"},
+			{JurisdictionID: "FR", DataCategory: "personal"},
+		},
+		Rules: []ZoneRule{
+			{ID: "banned", Zone: ZoneRed, When: ZoneCondition{
+				DataCategories: []string{"essential"},
+				Mappings:       []MappingCondition{{RegulationIDs: []string{"FR-SNC"}, Levels: []ComplianceLevel{ComplianceBanned}}},
+			}},
+			{ID: "qualified", Zone: ZoneGreen, When: ZoneCondition{
+				JurisdictionIDs: []string{"EU"},
+				DataCategories:  []string{"essential"},
+				Certifications:  []string{"secnumcloud"},
+			}},
+			{ID: "eu-owned", Zone: ZoneYellow, When: ZoneCondition{
+				DataCategories: []string{"essential"},
+				SolutionTypes:  []SolutionType{SolutionSovereign},
+				Ownership:      &OwnershipCondition{MinEUOwnershipPercent: &minEU},
+			}},
+		},
+	}
+}
+
+func TestDeriveZones(t *testing.T) {
+	cf := zoneRulesTestFramework()
+	result := cf.DeriveZones(zoneRulesTestRuleSet())
+
+	zones := make(map[string]DerivedZoneAssignment)
+	for _, a := range result.Assignments {
+		zones[a.SolutionID+"/"+a.DataCategory] = a
+	}
+	expected := map[string]string{
+		"aws/essential":  "banned",
+		"ovh/essential":  "qualified",
+		"bleu/essential": "eu-owned",
+	}
+	if len(zones) != len(expected) {
+		t.Errorf("expected %d computed zones, got %v", len(expected), zones)
+	}
+	for key, ruleID := range expected {
+		if zones[key].RuleID != ruleID {
+			t.Errorf("expected %s to be decided by %s, got %q", key, ruleID, zones[key].RuleID)
+		}
+	}
+	if z := zones["aws/essential"]; z.ID != "ZONE-AWS-FR-ESSENTIAL" || z.JurisdictionID != "FR" {
+		t.Errorf("unexpected derived assignment %+v", z)
+	}
+}
+
+func TestDeriveZonesDisagreements(t *testing.T) {
+	cf := zoneRulesTestFramework()
+	result := cf.DeriveZones(zoneRulesTestRuleSet())
+
+	byKind := make(map[ZoneDisagreementKind][]ZoneDisagreement)
+	for _, d := range result.Disagreements {
+		byKind[d.Kind] = append(byKind[d.Kind], d)
+	}
+
+	if m := byKind[ZoneMismatch]; len(m) != 1 || m[0].SolutionID != "bleu" || m[0].ManualZone != ZoneGreen || m[0].ComputedZone != ZoneYellow {
+		t.Errorf("expected bleu green/yellow mismatch, got %+v", m)
+	}
+	if n := byKind[ZoneNotAssigned]; len(n) != 1 || n[0].SolutionID != "ovh" || n[0].DataCategory != "essential" {
+		t.Errorf("expected ovh essential to be computed but not hand-entered, got %+v", n)
+	}
+	// Z3 is inherited from EU into the FR personal scope, where no rule matches.
+	if n := byKind[ZoneNotComputed]; len(n) != 1 || n[0].ManualID != "Z3" || !n[0].Inherited {
+		t.Errorf("expected inherited Z3 to be reported as not computed, got %+v", n)
+	}
+}
+
+func TestDeriveZonesDefaultScopes(t *testing.T) {
+	cf := zoneRulesTestFramework()
+	rs := zoneRulesTestRuleSet()
+	rs.Scopes = nil
+	rs.DefaultZone = ZoneGreen
+
+	result := cf.DeriveZones(rs)
+	for _, a := range result.Assignments {
+		if a.JurisdictionID == "EU" && a.SolutionID == "ovh" && (a.Zone != ZoneGreen || a.RuleID != "") {
+			t.Errorf("expected default zone for ovh in EU, got %+v", a)
+		}
+	}
+	for _, d := range result.Disagreements {
+		if d.Kind == ZoneNotComputed {
+			t.Errorf("expected default zone to cover every cell, got %+v", d)
+		}
+	}
+}
+
+func TestLoadZoneRuleSetRejectsUnknownMatch(t *testing.T) {
+	if _, err := LoadZoneRuleSet(filepath.Join("examples", "rules", "zone-rules.json")); err != nil {
+		t.Fatalf("loading the example rules failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	data := `{"rules": [{"id": "R1", "zone": "red", "when": {"mappings": [{"levels": ["banned"], "match": "al"}]}}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadZoneRuleSet(path)
+	var enumErr *EnumError
+	if !errors.As(err, &enumErr) || enumErr.Type != "MappingMatch" || enumErr.Value != "al" {
+		t.Errorf("expected an EnumError for match \"al\", got %v", err)
+	}
+}